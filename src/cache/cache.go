@@ -0,0 +1,265 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Entry is a cached response
+type Entry struct {
+	Body        []byte    `json:"body"`
+	ContentType string    `json:"content_type"`
+	ETag        string    `json:"etag"`
+	Expires     time.Time `json:"expires"`
+}
+
+// Immutable returns whether entry never expires
+func (e *Entry) Immutable() bool {
+	return e.Expires.IsZero()
+}
+
+// Stats holds cache statistics
+type Stats struct {
+	Hits       uint64 `json:"hits"`
+	Misses     uint64 `json:"misses"`
+	DiskHits   uint64 `json:"disk_hits"`
+	DiskErrors uint64 `json:"disk_errors"`
+	Evictions  uint64 `json:"evictions"`
+	Entries    int    `json:"entries"`
+	Bytes      int64  `json:"bytes"`
+	MaxBytes   int64  `json:"max_bytes"`
+	DiskFiles  int    `json:"disk_files"`
+	DiskBytes  int64  `json:"disk_bytes"`
+}
+
+// Options configure cache
+type Options struct {
+	// MaxBytes bounds size of response bodies held in memory
+	MaxBytes int64
+	// TTL is how long responses of latest height queries are cached
+	TTL time.Duration
+	// Dir is directory of on-disk tier for immutable responses, disabled
+	// if empty
+	Dir string
+	// MaxDiskBytes bounds size of files in on-disk tier, least recently
+	// used files are removed beyond it
+	MaxDiskBytes int64
+	// Skip holds paths that are never cached, such as streams
+	Skip map[string]bool
+	// Immutable returns whether responses of request never change, they
+	// are cached for TTL if nil or false
+	Immutable func(r *http.Request) bool
+	// Private returns whether responses depend on credentials of request,
+	// such as API keys, so that shared caches may not keep them
+	Private func() bool
+	// Vary lists request headers responses depend on when private
+	Vary string
+}
+
+// item is an entry held in LRU list
+type item struct {
+	key   string
+	entry *Entry
+}
+
+// diskFile is a file of on-disk tier held in LRU list
+type diskFile struct {
+	name string
+	size int64
+}
+
+// Cache is a size bounded LRU cache of responses with optional on-disk
+// tier for responses that never change.
+type Cache struct {
+	mutex     sync.Mutex
+	options   Options
+	lru       *list.List
+	items     map[string]*list.Element
+	diskLRU   *list.List
+	diskFiles map[string]*list.Element
+	stats     Stats
+}
+
+// New creates cache with given options, files left in on-disk tier by
+// earlier runs are kept within its size bound
+func New(options Options) *Cache {
+	c := &Cache{
+		options:   options,
+		lru:       list.New(),
+		items:     map[string]*list.Element{},
+		diskLRU:   list.New(),
+		diskFiles: map[string]*list.Element{},
+		stats:     Stats{MaxBytes: options.MaxBytes},
+	}
+	if len(options.Dir) > 0 {
+		os.MkdirAll(options.Dir, 0700)
+		c.loadDisk()
+	}
+	return c
+}
+
+// loadDisk tracks files already in on-disk tier, least recently modified
+// files are used least recently
+func (c *Cache) loadDisk() {
+	infos, err := ioutil.ReadDir(c.options.Dir)
+	if err != nil {
+		c.stats.DiskErrors++
+		return
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().After(infos[j].ModTime())
+	})
+	for _, info := range infos {
+		if info.Mode().IsRegular() {
+			c.diskFiles[info.Name()] = c.diskLRU.PushBack(
+				&diskFile{name: info.Name(), size: info.Size()})
+			c.stats.DiskBytes += info.Size()
+		}
+	}
+	c.trimDisk()
+}
+
+// trimDisk removes least recently used files until on-disk tier fits its
+// size bound
+func (c *Cache) trimDisk() {
+	for c.stats.DiskBytes > c.options.MaxDiskBytes && c.diskLRU.Len() > 0 {
+		file := c.diskLRU.Remove(c.diskLRU.Back()).(*diskFile)
+		delete(c.diskFiles, file.name)
+		c.stats.DiskBytes -= file.size
+		err := os.Remove(filepath.Join(c.options.Dir, file.name))
+		if err != nil && !os.IsNotExist(err) {
+			c.stats.DiskErrors++
+		}
+	}
+}
+
+// diskName returns name of file holding entry of key in on-disk tier
+func diskName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns entry of key if cached and not expired
+func (c *Cache) Get(key string) (*Entry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*item).entry
+		if entry.Immutable() || time.Now().Before(entry.Expires) {
+			c.lru.MoveToFront(element)
+			c.stats.Hits++
+			return entry, true
+		}
+		c.remove(element)
+	}
+
+	// Immutable entries evicted from memory may still be on disk
+	name := diskName(key)
+	if element, ok := c.diskFiles[name]; ok {
+		data, err := ioutil.ReadFile(filepath.Join(c.options.Dir, name))
+		if err == nil {
+			var entry Entry
+			if err = json.Unmarshal(data, &entry); err == nil {
+				c.stats.Hits++
+				c.stats.DiskHits++
+				c.diskLRU.MoveToFront(element)
+				c.add(key, &entry)
+				return &entry, true
+			}
+		}
+		c.stats.DiskErrors++
+		c.stats.DiskBytes -= c.diskLRU.Remove(element).(*diskFile).size
+		delete(c.diskFiles, name)
+	}
+
+	c.stats.Misses++
+	return nil, false
+}
+
+// Set caches entry of key, immutable entries are also written to disk
+func (c *Cache) Set(key string, entry *Entry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+	c.add(key, entry)
+
+	if entry.Immutable() && len(c.options.Dir) > 0 {
+		c.writeDisk(key, entry)
+	}
+}
+
+// writeDisk writes entry of key to on-disk tier, unless it alone exceeds
+// size bound of tier
+func (c *Cache) writeDisk(key string, entry *Entry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		c.stats.DiskErrors++
+		return
+	}
+	size := int64(len(data))
+	if size > c.options.MaxDiskBytes {
+		return
+	}
+
+	name := diskName(key)
+	if element, ok := c.diskFiles[name]; ok {
+		c.stats.DiskBytes -= c.diskLRU.Remove(element).(*diskFile).size
+		delete(c.diskFiles, name)
+	}
+	err = ioutil.WriteFile(filepath.Join(c.options.Dir, name), data, 0600)
+	if err != nil {
+		c.stats.DiskErrors++
+		return
+	}
+	c.diskFiles[name] = c.diskLRU.PushFront(
+		&diskFile{name: name, size: size})
+	c.stats.DiskBytes += size
+	c.trimDisk()
+}
+
+// Stats returns current cache statistics
+func (c *Cache) Stats() Stats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	stats.DiskFiles = c.diskLRU.Len()
+	return stats
+}
+
+// add puts entry in front of LRU list, evicting least recently used
+// entries until cache fits its size bound
+func (c *Cache) add(key string, entry *Entry) {
+	size := int64(len(entry.Body))
+	if size > c.options.MaxBytes {
+		return
+	}
+	c.items[key] = c.lru.PushFront(&item{key: key, entry: entry})
+	c.stats.Bytes += size
+
+	for c.stats.Bytes > c.options.MaxBytes {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove drops element from LRU list
+func (c *Cache) remove(element *list.Element) {
+	it := c.lru.Remove(element).(*item)
+	delete(c.items, it.key)
+	c.stats.Bytes -= int64(len(it.entry.Body))
+}
@@ -0,0 +1,225 @@
+package cache_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SimplyVC/oasis_api_server/src/cache"
+)
+
+func TestCache_Eviction(t *testing.T) {
+	c := cache.New(cache.Options{MaxBytes: 10})
+	c.Set("a", &cache.Entry{Body: []byte("aaaaa")})
+	c.Set("b", &cache.Entry{Body: []byte("bbbbb")})
+
+	// Using a makes b least recently used
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Expected a to be cached")
+	}
+	c.Set("c", &cache.Entry{Body: []byte("ccccc")})
+
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Errorf("Expected a to be cached")
+	}
+	if stats := c.Stats(); stats.Evictions != 1 || stats.Bytes != 10 {
+		t.Errorf("Unexpected stats got %+v", stats)
+	}
+}
+
+func TestCache_Expiry(t *testing.T) {
+	c := cache.New(cache.Options{MaxBytes: 100})
+	c.Set("a", &cache.Entry{Body: []byte("a"),
+		Expires: time.Now().Add(-time.Second)})
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Expected a to be expired")
+	}
+}
+
+func TestCache_Disk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := cache.New(cache.Options{MaxBytes: 5, Dir: dir, MaxDiskBytes: 1024})
+	c.Set("a", &cache.Entry{Body: []byte("aaaaa")})
+	c.Set("b", &cache.Entry{Body: []byte("bbbbb")})
+
+	entry, ok := c.Get("a")
+	if !ok || string(entry.Body) != "aaaaa" {
+		t.Errorf("Expected a to be read from disk")
+	}
+	if stats := c.Stats(); stats.DiskHits != 1 {
+		t.Errorf("Unexpected stats got %+v", stats)
+	}
+}
+
+func TestCache_DiskBound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Each entry takes 80 bytes on disk, only two of them fit
+	c := cache.New(cache.Options{MaxBytes: 5, Dir: dir, MaxDiskBytes: 200})
+	for _, key := range []string{"a", "b", "c"} {
+		c.Set(key, &cache.Entry{Body: []byte(key + key + key + key + key)})
+	}
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Expected a to be removed from disk")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Errorf("Expected b to be read from disk")
+	}
+	if stats := c.Stats(); stats.DiskFiles != 2 || stats.DiskBytes > 200 {
+		t.Errorf("Unexpected stats got %+v", stats)
+	}
+
+	// Files of earlier runs are kept within bound too
+	c = cache.New(cache.Options{MaxBytes: 5, Dir: dir, MaxDiskBytes: 100})
+	if stats := c.Stats(); stats.DiskFiles != 1 {
+		t.Errorf("Unexpected stats got %+v", stats)
+	}
+}
+
+func TestMiddleware_ETag(t *testing.T) {
+	calls := 0
+	c := cache.New(cache.Options{MaxBytes: 100, TTL: time.Minute})
+	handler := c.Middleware(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Header().Add("Content-Type", "application/json")
+			w.Write([]byte(`{"result":1}`))
+		}))
+
+	req, _ := http.NewRequest("GET", "/api/consensus/block?height=5", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	etag := rr.Header().Get("ETag")
+	if etag == "" || rr.Body.String() != `{"result":1}` {
+		t.Errorf("Unexpected response got %v", rr.Body.String())
+	}
+
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			rr.Code, http.StatusNotModified)
+	}
+	if calls != 1 {
+		t.Errorf("Expected handler to be called once got %v", calls)
+	}
+}
+
+func TestMiddleware_Error(t *testing.T) {
+	calls := 0
+	c := cache.New(cache.Options{MaxBytes: 100, TTL: time.Minute})
+	handler := c.Middleware(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(`{"error":"Failed"}`))
+		}))
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest("GET", "/api/consensus/block", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("Expected error not to be cached")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected handler to be called twice got %v", calls)
+	}
+}
+
+func TestMiddleware_Immutable(t *testing.T) {
+	c := cache.New(cache.Options{MaxBytes: 100, TTL: time.Minute,
+		Immutable: func(r *http.Request) bool {
+			return r.URL.Path == "/api/consensus/block"
+		}})
+	handler := c.Middleware(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"result":1}`))
+		}))
+
+	for path, immutable := range map[string]bool{
+		"/api/consensus/block?height=5":  true,
+		"/api/prometheus/gauge?height=5": false,
+	} {
+		req, _ := http.NewRequest("GET", path, nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		cacheControl := rr.Header().Get("Cache-Control")
+		if strings.HasSuffix(cacheControl, "immutable") != immutable {
+			t.Errorf("Unexpected Cache-Control of %s got %v", path,
+				cacheControl)
+		}
+	}
+}
+
+func TestMiddleware_Private(t *testing.T) {
+	private := false
+	c := cache.New(cache.Options{MaxBytes: 100, TTL: time.Minute,
+		Private: func() bool { return private }, Vary: "X-API-Key"})
+	handler := c.Middleware(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"result":1}`))
+		}))
+
+	for _, private = range []bool{false, true} {
+		req, _ := http.NewRequest("GET", "/api/consensus/block", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		cacheControl := rr.Header().Get("Cache-Control")
+		vary := rr.Header().Get("Vary")
+		if private && (!strings.HasPrefix(cacheControl, "private") ||
+			vary != "X-API-Key") {
+			t.Errorf("Expected private response got %v and Vary %v",
+				cacheControl, vary)
+		}
+		if !private && (!strings.HasPrefix(cacheControl, "public") ||
+			len(vary) > 0) {
+			t.Errorf("Expected public response got %v and Vary %v",
+				cacheControl, vary)
+		}
+	}
+}
+
+func TestMiddleware_PrivateKeys(t *testing.T) {
+	c := cache.New(cache.Options{MaxBytes: 1000, TTL: time.Minute,
+		Private: func() bool { return true }, Vary: "X-API-Key"})
+	handler := c.Middleware(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"result":"` + r.Header.Get("X-API-Key") +
+				`"}`))
+		}))
+
+	// Responses depending on API key aren't shared between keys
+	for _, key := range []string{"first", "second", "first"} {
+		req, _ := http.NewRequest("GET", "/api/consistency", nil)
+		req.Header.Set("X-API-Key", key)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		expected := `{"result":"` + key + `"}`
+		if rr.Body.String() != expected {
+			t.Errorf("Expected response %v got %v", expected,
+				rr.Body.String())
+		}
+	}
+}
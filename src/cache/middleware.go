@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Cache-Control max-age given to responses that never change
+const immutableMaxAge = 365 * 24 * 60 * 60
+
+// recorder captures response body so that it can be cached, headers are
+// written to underlying response writer directly
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+// visibility returns whether responses may be kept by shared caches, public,
+// or only by client, private. Private responses vary by headers carrying
+// credentials.
+func (c *Cache) visibility(w http.ResponseWriter) string {
+	if c.options.Private == nil || !c.options.Private() {
+		return "public"
+	}
+	if len(c.options.Vary) > 0 {
+		w.Header().Add("Vary", c.options.Vary)
+	}
+	return "private"
+}
+
+// requestKey returns key request is cached under, private responses are
+// cached apart for each value of headers they vary by, hashed so that
+// credentials aren't kept
+func (c *Cache) requestKey(r *http.Request) string {
+	key := r.URL.Path + "?" + r.URL.Query().Encode()
+	if c.options.Private == nil || !c.options.Private() ||
+		len(c.options.Vary) == 0 {
+		return key
+	}
+
+	hash := sha256.New()
+	for _, name := range strings.Split(c.options.Vary, ",") {
+		hash.Write([]byte(r.Header.Get(strings.TrimSpace(name)) + "\n"))
+	}
+	return key + "#" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// writeEntry responds with cached entry, or with not modified if client
+// already holds it
+func (c *Cache) writeEntry(w http.ResponseWriter, r *http.Request,
+	entry *Entry) {
+
+	w.Header().Set("ETag", entry.ETag)
+	visibility := c.visibility(w)
+	if entry.Immutable() {
+		w.Header().Set("Cache-Control", fmt.Sprintf(
+			"%s, max-age=%d, immutable", visibility, immutableMaxAge))
+	} else {
+		maxAge := int(time.Until(entry.Expires).Seconds())
+		if maxAge < 0 {
+			maxAge = 0
+		}
+		w.Header().Set("Cache-Control",
+			fmt.Sprintf("%s, max-age=%d", visibility, maxAge))
+	}
+
+	if r.Header.Get("If-None-Match") == entry.ETag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if len(entry.ContentType) > 0 {
+		w.Header().Set("Content-Type", entry.ContentType)
+	}
+	w.Write(entry.Body)
+}
+
+// Middleware answers GET requests from cache, caching successful responses
+// forever if they never change and for TTL otherwise.
+func (c *Cache) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || c.options.Skip[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := c.requestKey(r)
+		if entry, ok := c.Get(key); ok {
+			c.writeEntry(w, r, entry)
+			return
+		}
+
+		rec := &recorder{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		body := rec.body.Bytes()
+
+		// Errors are also answered with status OK, never cache them
+		if rec.status != http.StatusOK ||
+			bytes.HasPrefix(body, []byte(`{"error"`)) {
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(rec.status)
+			w.Write(body)
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		entry := &Entry{
+			Body:        append([]byte{}, body...),
+			ContentType: w.Header().Get("Content-Type"),
+			ETag:        `"` + hex.EncodeToString(sum[:16]) + `"`,
+		}
+		if c.options.Immutable == nil || !c.options.Immutable(r) {
+			if c.options.TTL <= 0 {
+				c.visibility(w)
+				w.Header().Set("Cache-Control", "no-cache")
+				w.Write(body)
+				return
+			}
+			entry.Expires = time.Now().Add(c.options.TTL)
+		}
+		c.Set(key, entry)
+		c.writeEntry(w, r, entry)
+	})
+}
@@ -0,0 +1,386 @@
+// Package collector periodically queries configured nodes for chain state
+// and exports it as Prometheus metrics labeled by node name and entity.
+package collector
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	mint_api "github.com/oasisprotocol/oasis-core/go/consensus/tendermint/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/tendermint/crypto"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
+	"github.com/SimplyVC/oasis_api_server/src/config"
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+)
+
+// Namespace of chain state metrics
+const namespace = "oasis_chain"
+
+// Labels of metrics of node, entity and validator node
+var (
+	nodeLabels      = []string{"node"}
+	entityLabels    = []string{"node", "entity"}
+	validatorLabels = []string{"node", "entity", "node_id"}
+)
+
+// newDesc returns description of chain state metric
+func newDesc(name string, help string, labels []string) *prometheus.Desc {
+	return prometheus.NewDesc(namespace+"_"+name, help, labels, nil)
+}
+
+// Descriptions of chain state metrics
+var (
+	upDesc = newDesc("up",
+		"Whether chain state was last collected from node.", nodeLabels)
+	heightDesc = newDesc("height",
+		"Latest block height of node.", nodeLabels)
+	blockTimeDesc = newDesc("block_time_seconds",
+		"Time of latest block of node, in seconds since epoch.", nodeLabels)
+	epochDesc = newDesc("epoch",
+		"Current epoch of node.", nodeLabels)
+	totalSupplyDesc = newDesc("total_supply",
+		"Total supply of tokens, in base units.", nodeLabels)
+	commonPoolDesc = newDesc("common_pool",
+		"Balance of common pool, in base units.", nodeLabels)
+	activeProposalsDesc = newDesc("active_proposals",
+		"Governance proposals that have not closed yet.", nodeLabels)
+	escrowDesc = newDesc("entity_escrow_balance",
+		"Active escrow balance of entity, in base units.", entityLabels)
+	commissionDesc = newDesc("entity_commission_rate",
+		"Current commission rate of entity, as a fraction.", entityLabels)
+	votingPowerDesc = newDesc("validator_voting_power",
+		"Consensus voting power of validator.", validatorLabels)
+	signedDesc = newDesc("validator_signed_blocks",
+		"Latest blocks signed by validator.", validatorLabels)
+	missedDesc = newDesc("validator_missed_blocks",
+		"Latest blocks not signed by validator.", validatorLabels)
+	expirationDesc = newDesc("node_expiration_epoch",
+		"Epoch registration of node expires in.", validatorLabels)
+)
+
+// Collector exports chain state last collected from each configured node
+type Collector struct {
+	// entities whose state is exported, entities of validators if empty
+	entities []signature.PublicKey
+	window   int
+
+	mutex   sync.RWMutex
+	metrics map[string][]prometheus.Metric
+	signers map[string]*signWindow
+}
+
+// New returns collector exporting state of entities, or of validator
+// entities if none are given, and counting signatures of validators over
+// window latest blocks
+func New(entities []signature.PublicKey, window int) *Collector {
+	return &Collector{
+		entities: entities,
+		window:   window,
+		metrics:  map[string][]prometheus.Metric{},
+		signers:  map[string]*signWindow{},
+	}
+}
+
+// Describe sends descriptions of chain state metrics
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{upDesc, heightDesc,
+		blockTimeDesc, epochDesc, totalSupplyDesc, commonPoolDesc,
+		activeProposalsDesc, escrowDesc, commissionDesc, votingPowerDesc,
+		signedDesc, missedDesc, expirationDesc} {
+		ch <- desc
+	}
+}
+
+// Collect sends chain state last collected from each node
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	for _, metrics := range c.metrics {
+		for _, metric := range metrics {
+			ch <- metric
+		}
+	}
+}
+
+// Start registers collector and collects chain state from every configured
+// node at given interval.
+func (c *Collector) Start(interval time.Duration) {
+	prometheus.MustRegister(c)
+
+	go func() {
+		for {
+			var wg sync.WaitGroup
+			for _, node := range config.GetNodes() {
+				wg.Add(1)
+				go func(name string, socket string) {
+					defer wg.Done()
+					ctx, cancel := context.WithTimeout(
+						context.Background(), interval)
+					defer cancel()
+					c.update(ctx, name, socket)
+				}(node["node_name"], node["isocket_path"])
+			}
+			wg.Wait()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// update replaces metrics of node with chain state collected from it, only
+// up is exported if collection fails
+func (c *Collector) update(ctx context.Context, name string,
+	socket string) {
+
+	metrics, err := c.collect(ctx, name, socket)
+	up := 1.0
+	if err != nil {
+		lgr.Error.Printf("Collecting chain state of node %s has failed : "+
+			"%s", name, err)
+		metrics, up = nil, 0
+	}
+	metrics = append(metrics, prometheus.MustNewConstMetric(upDesc,
+		prometheus.GaugeValue, up, name))
+
+	c.mutex.Lock()
+	c.metrics[name] = metrics
+	c.mutex.Unlock()
+}
+
+// toFloat returns quantity as float, losing precision of large quantities
+func toFloat(q *quantity.Quantity) float64 {
+	value, _ := new(big.Float).SetInt(q.ToBigInt()).Float64()
+	return value
+}
+
+// collect returns chain state metrics of node at its latest height
+func (c *Collector) collect(ctx context.Context, name string,
+	socket string) ([]prometheus.Metric, error) {
+
+	conn, err := rpc.Connect(socket)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	co := consensus.NewConsensusClient(conn)
+	blk, err := co.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, err
+	}
+	height := blk.Height
+
+	epoch, err := beacon.NewBeaconClient(conn).GetEpoch(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	so := staking.NewStakingClient(conn)
+	totalSupply, err := so.TotalSupply(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	commonPool, err := so.CommonPool(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	gauge := func(desc *prometheus.Desc, value float64,
+		labels ...string) prometheus.Metric {
+		return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue,
+			value, append([]string{name}, labels...)...)
+	}
+	metrics := []prometheus.Metric{
+		gauge(heightDesc, float64(height)),
+		gauge(blockTimeDesc, float64(blk.Time.Unix())),
+		gauge(epochDesc, float64(epoch)),
+		gauge(totalSupplyDesc, toFloat(totalSupply)),
+		gauge(commonPoolDesc, toFloat(commonPool)),
+	}
+
+	// Proposals are left out if node doesn't serve governance
+	proposals, err := governance.NewGovernanceClient(conn).ActiveProposals(
+		ctx, height)
+	if err != nil {
+		lgr.Warning.Printf("Collecting proposals of node %s has failed : "+
+			"%s", name, err)
+	} else {
+		metrics = append(metrics, gauge(activeProposalsDesc,
+			float64(len(proposals))))
+	}
+
+	validators, err := scheduler.NewSchedulerClient(conn).GetValidators(ctx,
+		height)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := registry.NewRegistryClient(conn).GetNodes(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	nodesByID := map[signature.PublicKey]*node.Node{}
+	for _, n := range nodes {
+		nodesByID[n.ID] = n
+	}
+
+	// Export entities configured, or else entities of validators
+	entities := c.entities
+	if len(entities) == 0 {
+		seen := map[signature.PublicKey]bool{}
+		for _, validator := range validators {
+			if n, ok := nodesByID[validator.ID]; ok && !seen[n.EntityID] {
+				seen[n.EntityID] = true
+				entities = append(entities, n.EntityID)
+			}
+		}
+	}
+	tracked := map[signature.PublicKey]bool{}
+	for _, entity := range entities {
+		tracked[entity] = true
+		account, err := so.Account(ctx, &staking.OwnerQuery{
+			Height: height, Owner: staking.NewAddress(entity)})
+		if err != nil {
+			return nil, err
+		}
+		rate := 0.0
+		if current := account.Escrow.CommissionSchedule.CurrentRate(
+			epoch); current != nil {
+			rate = toFloat(current) /
+				toFloat(staking.CommissionRateDenominator)
+		}
+		metrics = append(metrics,
+			gauge(escrowDesc, toFloat(&account.Escrow.Active.Balance),
+				entity.String()),
+			gauge(commissionDesc, rate, entity.String()))
+	}
+
+	// Signatures are counted over latest blocks, collected incrementally
+	window := c.signWindow(name)
+	if err = window.update(ctx, co, height); err != nil {
+		lgr.Warning.Printf("Collecting signatures of node %s has failed : "+
+			"%s", name, err)
+	}
+
+	for _, validator := range validators {
+		n, ok := nodesByID[validator.ID]
+		if !ok {
+			continue
+		}
+		labels := []string{n.EntityID.String(), n.ID.String()}
+		address := crypto.PublicKeyToTendermint(&n.Consensus.ID).Address()
+		signed, missed := window.counts(address.String())
+		metrics = append(metrics,
+			gauge(votingPowerDesc, float64(validator.VotingPower),
+				labels...),
+			gauge(signedDesc, float64(signed), labels...),
+			gauge(missedDesc, float64(missed), labels...))
+	}
+
+	// Registration expiry of nodes of exported entities
+	ids := []signature.PublicKey{}
+	for id, n := range nodesByID {
+		if tracked[n.EntityID] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	for _, id := range ids {
+		n := nodesByID[id]
+		metrics = append(metrics, gauge(expirationDesc,
+			float64(n.Expiration), n.EntityID.String(), n.ID.String()))
+	}
+	return metrics, nil
+}
+
+// signWindow returns signatures of latest blocks collected from node
+func (c *Collector) signWindow(name string) *signWindow {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	window, ok := c.signers[name]
+	if !ok {
+		window = &signWindow{size: c.window}
+		c.signers[name] = window
+	}
+	return window
+}
+
+// signWindow holds addresses of validators that signed each of latest
+// blocks, each node has its own and is updated by one goroutine at a time
+type signWindow struct {
+	size    int
+	latest  int64
+	signers []map[string]bool
+}
+
+// add adds validators that signed block following latest block
+func (w *signWindow) add(height int64, signers map[string]bool) {
+	w.signers = append(w.signers, signers)
+	if len(w.signers) > w.size {
+		w.signers = w.signers[len(w.signers)-w.size:]
+	}
+	w.latest = height
+}
+
+// counts returns number of blocks of window validator with address signed
+// and did not sign
+func (w *signWindow) counts(address string) (int, int) {
+	signed := 0
+	for _, signers := range w.signers {
+		if signers[address] {
+			signed++
+		}
+	}
+	return signed, len(w.signers) - signed
+}
+
+// update adds signatures of blocks up to height not seen yet, blocks are
+// signed by commit included in block following them
+func (w *signWindow) update(ctx context.Context,
+	co consensus.ClientBackend, height int64) error {
+
+	from := w.latest + 1
+	if from < height-int64(w.size)+1 || w.latest == 0 {
+		from = height - int64(w.size) + 1
+		w.signers = nil
+	}
+	if from < 2 {
+		from = 2
+	}
+	for h := from; h <= height; h++ {
+		blk, err := co.GetBlock(ctx, h)
+		if err != nil {
+			return err
+		}
+		var meta mint_api.BlockMeta
+		if err = cbor.Unmarshal(blk.Meta, &meta); err != nil {
+			return err
+		}
+
+		signers := map[string]bool{}
+		if meta.LastCommit != nil {
+			for _, sig := range meta.LastCommit.Signatures {
+				if sig.ForBlock() {
+					signers[sig.ValidatorAddress.String()] = true
+				}
+			}
+		}
+		w.add(h, signers)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+// Testing if signatures are counted over latest blocks of window only
+func TestSignWindow_Counts(t *testing.T) {
+	window := &signWindow{size: 3}
+	window.add(2, map[string]bool{"A": true, "B": true})
+	window.add(3, map[string]bool{"A": true})
+	window.add(4, map[string]bool{"B": true})
+	window.add(5, map[string]bool{"B": true})
+
+	if signed, missed := window.counts("A"); signed != 1 || missed != 2 {
+		t.Errorf("Expected A to sign 1 and miss 2 blocks got %v and %v",
+			signed, missed)
+	}
+	if signed, missed := window.counts("B"); signed != 2 || missed != 1 {
+		t.Errorf("Expected B to sign 2 and miss 1 blocks got %v and %v",
+			signed, missed)
+	}
+	if window.latest != 5 {
+		t.Errorf("Expected latest height 5 got %v", window.latest)
+	}
+}
+
+// Testing if quantities are converted to floats
+func TestToFloat(t *testing.T) {
+	q := quantity.NewFromUint64(1500000000)
+	if value := toFloat(q); value != 1500000000 {
+		t.Errorf("Expected 1500000000 got %v", value)
+	}
+}
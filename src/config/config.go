@@ -11,9 +11,13 @@ var (
 	confMain       ini.Config
 	confNodes      ini.Config
 	confSentry     ini.Config
+	confSigners    ini.Config
+	confKeys       ini.Config
 	mainConfigFile = "../config/user_config_main.ini"
 	nodesFile      = "../config/user_config_nodes.ini"
 	sentryFile     = "../config/user_config_sentry.ini"
+	signersFile    = "../config/user_config_signers.ini"
+	keysFile       = "../config/user_config_keys.ini"
 )
 
 // SetSentryFile sets file location containing sentry data
@@ -21,6 +25,16 @@ func SetSentryFile(newFile string) {
 	sentryFile = newFile
 }
 
+// SetSignersFile sets file location containing signer data
+func SetSignersFile(newFile string) {
+	signersFile = newFile
+}
+
+// SetKeysFile sets file location containing API key data
+func SetKeysFile(newFile string) {
+	keysFile = newFile
+}
+
 // SetMainFile sets file location containing API configuration
 func SetMainFile(newFile string) {
 	mainConfigFile = newFile
@@ -36,6 +50,16 @@ func GetSentryData() map[string]map[string]string {
 	return confSentry
 }
 
+// GetSigners returns Signers configuration
+func GetSigners() map[string]map[string]string {
+	return confSigners
+}
+
+// GetKeys returns API keys configuration
+func GetKeys() map[string]map[string]string {
+	return confKeys
+}
+
 // GetMain returns Main API configuration
 func GetMain() map[string]map[string]string {
 	return confMain
@@ -78,3 +102,25 @@ func LoadSentryConfiguration() (map[string]map[string]string, error) {
 	}
 	return confSentry, nil
 }
+
+// LoadSignersConfiguration loads signers configuration details
+func LoadSignersConfiguration() (map[string]map[string]string, error) {
+
+	// Decode and read file containing signer information
+	if err := ini.DecodeFile(signersFile, &confSigners); err != nil {
+		lgr.Error.Println(err)
+		return nil, err
+	}
+	return confSigners, nil
+}
+
+// LoadKeysConfiguration loads API keys configuration details
+func LoadKeysConfiguration() (map[string]map[string]string, error) {
+
+	// Decode and read file containing API key information
+	if err := ini.DecodeFile(keysFile, &confKeys); err != nil {
+		lgr.Error.Println(err)
+		return nil, err
+	}
+	return confKeys, nil
+}
@@ -22,8 +22,9 @@ require (
 	github.com/gorilla/mux v1.7.4
 	github.com/mackerelio/go-osstat v0.1.0
 	github.com/oasisprotocol/oasis-core/go v0.2100.1
-	github.com/prometheus/common v0.14.0
-	github.com/tendermint/tendermint v0.34.8-oasis1
+	github.com/prometheus/client_golang v1.9.0
+	github.com/prometheus/common v0.19.0
+	github.com/tendermint/tendermint v0.34.8
 	github.com/zenazn/goji v0.9.0
-	google.golang.org/grpc v1.35.0
+	google.golang.org/grpc v1.36.0
 )
@@ -0,0 +1,376 @@
+// Package graphql implements the subset of GraphQL needed to serve read-only
+// queries: operations, fields, aliases, arguments, variables, fragments and
+// introspection. Directives and mutations are not supported.
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Arguments holds arguments of a field with variables already substituted
+type Arguments map[string]interface{}
+
+// String returns string argument and whether it was given
+func (a Arguments) String(name string) (string, bool) {
+	value, ok := a[name].(string)
+	return value, ok
+}
+
+// Int returns integer argument and whether it was given, variables decoded
+// from JSON are accepted as long as they hold whole numbers
+func (a Arguments) Int(name string) (int64, bool) {
+	switch value := a[name].(type) {
+	case int64:
+		return value, true
+	case float64:
+		if value == float64(int64(value)) {
+			return int64(value), true
+		}
+	}
+	return 0, false
+}
+
+// ResolveFunc returns value of field for source object
+type ResolveFunc func(source interface{}, args Arguments) (interface{}, error)
+
+// FieldDef defines field of an object type
+type FieldDef struct {
+	// Type is object type of field values, nil for scalars
+	Type *Object
+	// Scalar is name of scalar type of field values if Type is nil, JSON
+	// if empty
+	Scalar string
+	// List tells that field values are lists
+	List bool
+	// Args describes arguments of field
+	Args []*ArgDef
+	// Resolve returns field value, slices are returned as lists. If nil the
+	// value is looked up by field name in a map[string]interface{} source.
+	Resolve ResolveFunc
+}
+
+// ArgDef describes argument of a field
+type ArgDef struct {
+	Name string
+	// Type of argument in GraphQL notation, such as String!
+	Type string
+}
+
+// Object is an object type of a schema
+type Object struct {
+	Name   string
+	Fields map[string]*FieldDef
+}
+
+// Schema holds root query type and limits of queries executed against it
+type Schema struct {
+	Query *Object
+
+	// MaxDepth bounds nesting of selected fields, unbounded if 0
+	MaxDepth int
+	// MaxComplexity bounds number of fields resolved by query, fields of
+	// list values counting listComplexity times. Unbounded if 0.
+	MaxComplexity int
+}
+
+// Number of elements lists are assumed to hold when measuring complexity
+const listComplexity = 10
+
+// Error is an error of query, with path to field that failed if any
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// Result is response to a query
+type Result struct {
+	Data   *OrderedObject `json:"data"`
+	Errors []*Error       `json:"errors,omitempty"`
+}
+
+// OrderedObject is a JSON object keeping its keys in selection order
+type OrderedObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Set sets value of key, appending key if it's new
+func (o *OrderedObject) Set(key string, value interface{}) {
+	if o.values == nil {
+		o.values = map[string]interface{}{}
+	}
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// Get returns value of key
+func (o *OrderedObject) Get(key string) (interface{}, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// MarshalJSON encodes object with keys in order they were set
+func (o *OrderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// executor holds state of a single query execution
+type executor struct {
+	schema    *Schema
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// Execute runs query against schema, resolving only the fields selected
+func Execute(schema *Schema, query string, variables map[string]interface{},
+	operationName string) *Result {
+
+	operations, err := Parse(query)
+	if err != nil {
+		return &Result{Errors: []*Error{{Message: err.Error()}}}
+	}
+
+	var operation *Operation
+	for _, op := range operations {
+		if len(operationName) == 0 || op.Name == operationName {
+			if operation != nil {
+				return &Result{Errors: []*Error{{Message: "Must provide " +
+					"operation name if query contains multiple operations"}}}
+			}
+			operation = op
+		}
+	}
+	if operation == nil {
+		return &Result{Errors: []*Error{{Message: "Unknown operation " +
+			"named " + operationName}}}
+	}
+	if operation.Type != "query" {
+		return &Result{Errors: []*Error{{Message: "Only query operations " +
+			"are supported"}}}
+	}
+
+	// Queries are measured before any field is resolved
+	depth, complexity := measure(schema.Query, operation.Selections,
+		schema.MaxComplexity)
+	if schema.MaxDepth > 0 && depth > schema.MaxDepth {
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("Query "+
+			"depth exceeds maximum depth of %d", schema.MaxDepth)}}}
+	}
+	if schema.MaxComplexity > 0 && complexity > schema.MaxComplexity {
+		return &Result{Errors: []*Error{{Message: fmt.Sprintf("Query "+
+			"complexity exceeds maximum complexity of %d",
+			schema.MaxComplexity)}}}
+	}
+
+	e := &executor{schema: schema, variables: map[string]interface{}{}}
+	for name, value := range operation.Defaults {
+		e.variables[name] = value
+	}
+	for name, value := range variables {
+		e.variables[name] = value
+	}
+
+	data := e.selections(schema.Query, nil, operation.Selections, nil)
+	return &Result{Data: data, Errors: e.errors}
+}
+
+// collect returns fields selected on object of given type, with selections
+// of fragments applying to the type in place of the fragments
+func collect(object *Object, fields []*Field) []*Field {
+	collected := []*Field{}
+	for _, field := range fields {
+		if field.Name != fragmentName {
+			collected = append(collected, field)
+		} else if len(field.On) == 0 || field.On == object.Name {
+			collected = append(collected,
+				collect(object, field.Selections)...)
+		}
+	}
+	return collected
+}
+
+// measure returns depth of fields selected on object of given type and
+// number of fields resolved, counting fields of lists listComplexity times.
+// Measuring stops once complexity exceeds limit, unless limit is 0.
+func measure(object *Object, fields []*Field, limit int) (int, int) {
+	depth, complexity := 0, 0
+	for _, field := range collect(object, fields) {
+		fieldDepth, fieldComplexity := 1, 1
+		if def, ok := object.Fields[field.Name]; ok && def.Type != nil {
+			childDepth, childComplexity := measure(def.Type,
+				field.Selections, limit)
+			if def.List {
+				childComplexity *= listComplexity
+			}
+			fieldDepth += childDepth
+			fieldComplexity += childComplexity
+		}
+		if limit > 0 && fieldComplexity > limit {
+			fieldComplexity = limit + 1
+		}
+		if fieldDepth > depth {
+			depth = fieldDepth
+		}
+		complexity += fieldComplexity
+		if limit > 0 && complexity > limit {
+			break
+		}
+	}
+	return depth, complexity
+}
+
+// fail records error of field at path
+func (e *executor) fail(path []interface{}, format string,
+	args ...interface{}) {
+
+	e.errors = append(e.errors, &Error{
+		Message: fmt.Sprintf(format, args...),
+		Path:    append([]interface{}{}, path...),
+	})
+}
+
+// arguments substitutes variables in argument values
+func (e *executor) arguments(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case variable:
+		resolved, ok := e.variables[string(v)]
+		if !ok {
+			return nil, fmt.Errorf("Variable $%s is not defined", v)
+		}
+		return resolved, nil
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			resolved, err := e.arguments(v[i])
+			if err != nil {
+				return nil, err
+			}
+			list[i] = resolved
+		}
+		return list, nil
+	case map[string]interface{}:
+		object := map[string]interface{}{}
+		for key := range v {
+			resolved, err := e.arguments(v[key])
+			if err != nil {
+				return nil, err
+			}
+			object[key] = resolved
+		}
+		return object, nil
+	}
+	return value, nil
+}
+
+// selections resolves fields selected on source of object type
+func (e *executor) selections(object *Object, source interface{},
+	fields []*Field, path []interface{}) *OrderedObject {
+
+	result := &OrderedObject{}
+	for _, field := range collect(object, fields) {
+		fieldPath := append(path, field.Key())
+
+		if field.Name == "__typename" {
+			result.Set(field.Key(), object.Name)
+			continue
+		}
+
+		def, ok := object.Fields[field.Name]
+		if !ok && object == e.schema.Query {
+			def, ok = e.schema.metaFields()[field.Name]
+		}
+		if !ok {
+			e.fail(fieldPath, "Cannot query field %q on type %q",
+				field.Name, object.Name)
+			result.Set(field.Key(), nil)
+			continue
+		}
+
+		args := Arguments{}
+		var err error
+		for name, value := range field.Arguments {
+			if args[name], err = e.arguments(value); err != nil {
+				break
+			}
+		}
+
+		var value interface{}
+		if err == nil {
+			if def.Resolve != nil {
+				value, err = def.Resolve(source, args)
+			} else if values, ok := source.(map[string]interface{}); ok {
+				value = values[field.Name]
+			}
+		}
+		if err != nil {
+			e.fail(fieldPath, "%s", err.Error())
+			result.Set(field.Key(), nil)
+			continue
+		}
+
+		result.Set(field.Key(), e.complete(def.Type, value, field, fieldPath))
+	}
+	return result
+}
+
+// complete turns resolved value into response value, resolving selections
+// of object values and of each element of lists
+func (e *executor) complete(object *Object, value interface{}, field *Field,
+	path []interface{}) interface{} {
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	if object == nil {
+		if len(field.Selections) > 0 {
+			e.fail(path, "Field %q must not have a selection since it "+
+				"is a scalar", field.Name)
+			return nil
+		}
+		return value
+	}
+	if len(field.Selections) == 0 {
+		e.fail(path, "Field %q of type %q must have a selection of "+
+			"subfields", field.Name, object.Name)
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = e.complete(object, v.Index(i).Interface(), field,
+				append(path, i))
+		}
+		return list
+	}
+	return e.selections(object, value, field.Selections, path)
+}
@@ -0,0 +1,309 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SimplyVC/oasis_api_server/src/graphql"
+)
+
+// testSchema has an entity with nodes and a failing field
+func testSchema(calls *int) *graphql.Schema {
+	nodeType := &graphql.Object{Name: "Node", Fields: map[string]*graphql.FieldDef{
+		"id": {},
+	}}
+	entityType := &graphql.Object{Name: "Entity", Fields: map[string]*graphql.FieldDef{
+		"id": {},
+		"nodes": {Type: nodeType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			*calls++
+			return []map[string]interface{}{{"id": "a"}, {"id": "b"}}, nil
+		}},
+		"broken": {Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			return nil, errors.New("Failed to retrieve Broken!")
+		}},
+	}}
+	return &graphql.Schema{Query: &graphql.Object{Name: "Query",
+		Fields: map[string]*graphql.FieldDef{
+			"entity": {Type: entityType, Resolve: func(source interface{},
+				args graphql.Arguments) (interface{}, error) {
+				id, _ := args.String("id")
+				return map[string]interface{}{"id": id}, nil
+			}},
+			"double": {Resolve: func(source interface{},
+				args graphql.Arguments) (interface{}, error) {
+				value, ok := args.Int("value")
+				if !ok {
+					return nil, errors.New("value is required")
+				}
+				return value * 2, nil
+			}},
+		}}}
+}
+
+// execute runs query against test schema and returns encoded result
+func execute(t *testing.T, query string, variables map[string]interface{},
+	calls *int) string {
+
+	result := graphql.Execute(testSchema(calls), query, variables, "")
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestExecute_Selection(t *testing.T) {
+	calls := 0
+	got := execute(t, `{ e: entity(id: "x") { id __typename } }`, nil, &calls)
+	expected := `{"data":{"e":{"id":"x","__typename":"Entity"}}}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+
+	// Nodes are only resolved when selected
+	if calls != 0 {
+		t.Errorf("Expected nodes not to be resolved got %v calls", calls)
+	}
+}
+
+func TestExecute_List(t *testing.T) {
+	calls := 0
+	got := execute(t, `query Q($id: String = "x") {
+		entity(id: $id) { nodes { id } }
+	}`, nil, &calls)
+	expected := `{"data":{"entity":{"nodes":[{"id":"a"},{"id":"b"}]}}}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_Variables(t *testing.T) {
+	calls := 0
+	got := execute(t, `query ($v: Int!) { double(value: $v) }`,
+		map[string]interface{}{"v": float64(21)}, &calls)
+	expected := `{"data":{"double":42}}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_FieldError(t *testing.T) {
+	calls := 0
+	got := execute(t, `{ entity(id: "x") { id broken } }`, nil, &calls)
+	expected := `{"data":{"entity":{"id":"x","broken":null}},"errors":[` +
+		`{"message":"Failed to retrieve Broken!","path":["entity","broken"]}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_UnknownField(t *testing.T) {
+	calls := 0
+	got := execute(t, `{ unicorn }`, nil, &calls)
+	expected := `{"data":{"unicorn":null},"errors":[` +
+		`{"message":"Cannot query field \"unicorn\" on type \"Query\"",` +
+		`"path":["unicorn"]}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_SyntaxError(t *testing.T) {
+	calls := 0
+	got := execute(t, `{ entity(id: "x") { id }`, nil, &calls)
+	expected := `{"data":null,"errors":[` +
+		`{"message":"Syntax Error: unexpected end of document"}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_Nesting(t *testing.T) {
+	calls := 0
+
+	// Deeply nested values and selections are rejected while parsing
+	for _, query := range []string{
+		`{ double(value: ` + strings.Repeat("[", 100000) + ` }`,
+		`{ double(value: ` + strings.Repeat("{a: ", 100000) + ` }`,
+		strings.Repeat("{ entity ", 100000),
+	} {
+		got := execute(t, query, nil, &calls)
+		if !strings.Contains(got, "nesting exceeds maximum of 64") {
+			t.Errorf("Expected nesting error got %.200v", got)
+		}
+	}
+
+	// Nesting within bound is parsed
+	got := execute(t, `{ double(value: [[[1]]]) }`, nil, &calls)
+	expected := `{"data":{"double":null},"errors":[` +
+		`{"message":"value is required","path":["double"]}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_Mutation(t *testing.T) {
+	calls := 0
+	got := execute(t, `mutation { double(value: 1) }`, nil, &calls)
+	expected := `{"data":null,"errors":[` +
+		`{"message":"Only query operations are supported"}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_Fragments(t *testing.T) {
+	calls := 0
+	got := execute(t, `{ entity(id: "x") { ...Ids ... on Node { unicorn } } }
+	fragment Ids on Entity { id nodes { ... { id } } }`, nil, &calls)
+	expected := `{"data":{"entity":{"id":"x","nodes":[{"id":"a"},{"id":"b"}]}}}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+
+	got = execute(t, `{ ...A } fragment A on Query { ...B }
+	fragment B on Query { ...A }`, nil, &calls)
+	expected = `{"data":null,"errors":[` +
+		`{"message":"Cannot spread fragment \"A\" within itself"}]}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+func TestExecute_Limits(t *testing.T) {
+	calls := 0
+	schema := testSchema(&calls)
+	for _, limits := range []struct {
+		depth      int
+		complexity int
+		query      string
+		expected   string
+	}{
+		{2, 0, `{ entity(id: "x") { nodes { id } } }`, `{"data":null,` +
+			`"errors":[{"message":"Query depth exceeds maximum depth ` +
+			`of 2"}]}`},
+		{0, 20, `{ a: entity(id: "x") { nodes { id } } ` +
+			`b: entity(id: "x") { nodes { id } } }`, `{"data":null,` +
+			`"errors":[{"message":"Query complexity exceeds maximum ` +
+			`complexity of 20"}]}`},
+		{2, 20, `{ entity(id: "x") { id } }`,
+			`{"data":{"entity":{"id":"x"}}}`},
+	} {
+		schema.MaxDepth = limits.depth
+		schema.MaxComplexity = limits.complexity
+		data, _ := json.Marshal(graphql.Execute(schema, limits.query, nil,
+			""))
+		if string(data) != limits.expected {
+			t.Errorf("Unexpected result got %s want %v", data,
+				limits.expected)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("Expected queries over limits not to be resolved")
+	}
+}
+
+func TestExecute_Introspection(t *testing.T) {
+	calls := 0
+	got := execute(t, `{
+		__schema { queryType { name } }
+		__type(name: "Entity") {
+			kind name fields { name type { ...TypeRef } }
+		}
+	}
+	fragment TypeRef on __Type { kind name ofType { kind name } }`,
+		nil, &calls)
+	expected := `{"data":{"__schema":{"queryType":{"name":"Query"}},` +
+		`"__type":{"kind":"OBJECT","name":"Entity","fields":[` +
+		`{"name":"broken","type":{"kind":"SCALAR","name":"JSON","ofType":null}},` +
+		`{"name":"id","type":{"kind":"SCALAR","name":"JSON","ofType":null}},` +
+		`{"name":"nodes","type":{"kind":"LIST","name":null,` +
+		`"ofType":{"kind":"OBJECT","name":"Node"}}}]}}}`
+	if got != expected {
+		t.Errorf("Unexpected result got %v want %v", got, expected)
+	}
+}
+
+// introspectionQuery is the query GraphiQL sends to learn schema
+const introspectionQuery = `
+  query IntrospectionQuery {
+    __schema {
+      queryType { name }
+      mutationType { name }
+      subscriptionType { name }
+      types { ...FullType }
+      directives {
+        name
+        description
+        locations
+        args { ...InputValue }
+      }
+    }
+  }
+  fragment FullType on __Type {
+    kind
+    name
+    description
+    fields(includeDeprecated: true) {
+      name
+      description
+      args { ...InputValue }
+      type { ...TypeRef }
+      isDeprecated
+      deprecationReason
+    }
+    inputFields { ...InputValue }
+    interfaces { ...TypeRef }
+    enumValues(includeDeprecated: true) {
+      name
+      description
+      isDeprecated
+      deprecationReason
+    }
+    possibleTypes { ...TypeRef }
+  }
+  fragment InputValue on __InputValue {
+    name
+    description
+    type { ...TypeRef }
+    defaultValue
+  }
+  fragment TypeRef on __Type {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType { kind name }
+        }
+      }
+    }
+  }`
+
+func TestExecute_IntrospectionQuery(t *testing.T) {
+	calls := 0
+	schema := testSchema(&calls)
+	schema.MaxDepth = 3
+	schema.MaxComplexity = 20
+
+	result := graphql.Execute(schema, introspectionQuery, nil, "")
+	if len(result.Errors) > 0 {
+		t.Fatalf("Unexpected errors got %v", result.Errors[0].Message)
+	}
+	data, _ := json.Marshal(result)
+	for _, name := range []string{"Query", "Entity", "Node", "JSON"} {
+		if !strings.Contains(string(data), `"name":"`+name+`"`) {
+			t.Errorf("Expected type %s to be introspected", name)
+		}
+	}
+}
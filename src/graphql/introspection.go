@@ -0,0 +1,198 @@
+package graphql
+
+import (
+	"sort"
+	"strings"
+)
+
+// Scalar types every schema describes, fields are JSON if not declared
+var builtinScalars = []string{"Boolean", "Float", "ID", "Int", "JSON",
+	"String"}
+
+// Types of the introspection system, introspected values are described by
+// maps keyed by field name
+var (
+	metaType       = &Object{Name: "__Type"}
+	metaField      = &Object{Name: "__Field"}
+	metaInputValue = &Object{Name: "__InputValue"}
+	metaEnumValue  = &Object{Name: "__EnumValue"}
+	metaDirective  = &Object{Name: "__Directive"}
+	metaSchema     = &Object{Name: "__Schema"}
+)
+
+func init() {
+	metaType.Fields = map[string]*FieldDef{
+		"kind":           {Scalar: "String"},
+		"name":           {Scalar: "String"},
+		"description":    {Scalar: "String"},
+		"specifiedByURL": {Scalar: "String"},
+		"fields":         {Type: metaField, List: true},
+		"interfaces":     {Type: metaType, List: true},
+		"possibleTypes":  {Type: metaType, List: true},
+		"enumValues":     {Type: metaEnumValue, List: true},
+		"inputFields":    {Type: metaInputValue, List: true},
+		"ofType":         {Type: metaType},
+	}
+	metaField.Fields = map[string]*FieldDef{
+		"name":              {Scalar: "String"},
+		"description":       {Scalar: "String"},
+		"args":              {Type: metaInputValue, List: true},
+		"type":              {Type: metaType},
+		"isDeprecated":      {Scalar: "Boolean"},
+		"deprecationReason": {Scalar: "String"},
+	}
+	metaInputValue.Fields = map[string]*FieldDef{
+		"name":              {Scalar: "String"},
+		"description":       {Scalar: "String"},
+		"type":              {Type: metaType},
+		"defaultValue":      {Scalar: "String"},
+		"isDeprecated":      {Scalar: "Boolean"},
+		"deprecationReason": {Scalar: "String"},
+	}
+	metaEnumValue.Fields = map[string]*FieldDef{
+		"name":              {Scalar: "String"},
+		"description":       {Scalar: "String"},
+		"isDeprecated":      {Scalar: "Boolean"},
+		"deprecationReason": {Scalar: "String"},
+	}
+	metaDirective.Fields = map[string]*FieldDef{
+		"name":         {Scalar: "String"},
+		"description":  {Scalar: "String"},
+		"locations":    {Scalar: "String", List: true},
+		"args":         {Type: metaInputValue, List: true},
+		"isRepeatable": {Scalar: "Boolean"},
+	}
+	metaSchema.Fields = map[string]*FieldDef{
+		"description":      {Scalar: "String"},
+		"queryType":        {Type: metaType},
+		"mutationType":     {Type: metaType},
+		"subscriptionType": {Type: metaType},
+		"types":            {Type: metaType, List: true},
+		"directives":       {Type: metaDirective, List: true},
+	}
+}
+
+// metaFields returns fields of root query type introspecting schema
+func (s *Schema) metaFields() map[string]*FieldDef {
+	return map[string]*FieldDef{
+		"__schema": {Type: metaSchema, Resolve: func(source interface{},
+			args Arguments) (interface{}, error) {
+			types := []interface{}{}
+			for _, name := range s.typeNames() {
+				types = append(types, s.describeType(name))
+			}
+			return map[string]interface{}{
+				"queryType":  s.describeType(s.Query.Name),
+				"types":      types,
+				"directives": []interface{}{},
+			}, nil
+		}},
+		"__type": {Type: metaType, Args: []*ArgDef{{Name: "name",
+			Type: "String!"}}, Resolve: func(source interface{},
+			args Arguments) (interface{}, error) {
+			name, _ := args.String("name")
+			return s.describeType(name), nil
+		}},
+	}
+}
+
+// objects returns object types reachable from root query type by name
+func (s *Schema) objects() map[string]*Object {
+	objects := map[string]*Object{}
+	var visit func(object *Object)
+	visit = func(object *Object) {
+		if _, ok := objects[object.Name]; ok {
+			return
+		}
+		objects[object.Name] = object
+		for _, def := range object.Fields {
+			if def.Type != nil {
+				visit(def.Type)
+			}
+		}
+	}
+	visit(s.Query)
+	return objects
+}
+
+// typeNames returns sorted names of scalar and object types of schema
+func (s *Schema) typeNames() []string {
+	names := append([]string{}, builtinScalars...)
+	for name := range s.objects() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// describeType returns introspection of type named name, nil if schema has
+// no such type
+func (s *Schema) describeType(name string) map[string]interface{} {
+	for _, scalar := range builtinScalars {
+		if scalar == name {
+			return map[string]interface{}{"kind": "SCALAR", "name": name}
+		}
+	}
+	object, ok := s.objects()[name]
+	if !ok {
+		return nil
+	}
+
+	names := []string{}
+	for fieldName := range object.Fields {
+		names = append(names, fieldName)
+	}
+	sort.Strings(names)
+
+	fields := []interface{}{}
+	for _, fieldName := range names {
+		def := object.Fields[fieldName]
+		args := []interface{}{}
+		for _, arg := range def.Args {
+			args = append(args, map[string]interface{}{
+				"name": arg.Name,
+				"type": typeRef(arg.Type),
+			})
+		}
+		fields = append(fields, map[string]interface{}{
+			"name":         fieldName,
+			"args":         args,
+			"type":         fieldTypeRef(def),
+			"isDeprecated": false,
+		})
+	}
+	return map[string]interface{}{
+		"kind":       "OBJECT",
+		"name":       object.Name,
+		"fields":     fields,
+		"interfaces": []interface{}{},
+	}
+}
+
+// fieldTypeRef returns reference to type of field values
+func fieldTypeRef(def *FieldDef) map[string]interface{} {
+	ref := map[string]interface{}{"kind": "SCALAR", "name": "JSON"}
+	if def.Type != nil {
+		ref = map[string]interface{}{"kind": "OBJECT", "name": def.Type.Name}
+	} else if len(def.Scalar) > 0 {
+		ref["name"] = def.Scalar
+	}
+	if def.List {
+		ref = map[string]interface{}{"kind": "LIST", "ofType": ref}
+	}
+	return ref
+}
+
+// typeRef returns reference to type given in GraphQL notation, such as
+// [String!]!
+func typeRef(notation string) map[string]interface{} {
+	if strings.HasSuffix(notation, "!") {
+		return map[string]interface{}{"kind": "NON_NULL",
+			"ofType": typeRef(strings.TrimSuffix(notation, "!"))}
+	}
+	if strings.HasPrefix(notation, "[") && strings.HasSuffix(notation, "]") {
+		return map[string]interface{}{"kind": "LIST",
+			"ofType": typeRef(notation[1 : len(notation)-1])}
+	}
+	return map[string]interface{}{"kind": "SCALAR", "name": notation}
+}
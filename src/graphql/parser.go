@@ -0,0 +1,585 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Kinds of lexical tokens
+const (
+	tokenEOF = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a lexical token of a query document
+type token struct {
+	kind  int
+	value string
+	pos   int
+}
+
+// Field is a field selected in a query. Fragments are selected as fields
+// named ..., whose selections apply to objects of type On, or to any object
+// if On is empty.
+type Field struct {
+	Alias      string
+	Name       string
+	Arguments  map[string]interface{}
+	Selections []*Field
+	On         string
+
+	// spread is name of fragment spread, replaced by its selections once
+	// document is parsed
+	spread string
+}
+
+// fragmentName is name of fields selecting fragments
+const fragmentName = "..."
+
+// Key returns name under which field is returned
+func (f *Field) Key() string {
+	if len(f.Alias) > 0 {
+		return f.Alias
+	}
+	return f.Name
+}
+
+// Operation is a query operation of a document
+type Operation struct {
+	Type       string
+	Name       string
+	Defaults   map[string]interface{}
+	Selections []*Field
+}
+
+// fragment is a named fragment of a document
+type fragment struct {
+	on         string
+	selections []*Field
+}
+
+// variable is a reference to a query variable in an argument value
+type variable string
+
+// lex splits query document into tokens
+func lex(source string) ([]token, error) {
+	tokens := []token{}
+	i := 0
+	for i < len(source) {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' ||
+			c == 0xef || c == 0xbb || c == 0xbf:
+			i++
+		case c == '#':
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "..."):
+			tokens = append(tokens, token{tokenPunctuator, "...", i})
+			i += 3
+		case strings.ContainsRune("!$():=@[]{}|", rune(c)):
+			tokens = append(tokens, token{tokenPunctuator, string(c), i})
+			i++
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			start := i
+			for i < len(source) && (source[i] == '_' ||
+				(source[i] >= 'a' && source[i] <= 'z') ||
+				(source[i] >= 'A' && source[i] <= 'Z') ||
+				(source[i] >= '0' && source[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, token{tokenName, source[start:i], start})
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := i
+			kind := tokenInt
+			i++
+			for i < len(source) && strings.ContainsRune(
+				"0123456789.eE+-", rune(source[i])) {
+				if strings.ContainsRune(".eE", rune(source[i])) {
+					kind = tokenFloat
+				}
+				i++
+			}
+			tokens = append(tokens, token{kind, source[start:i], start})
+		case c == '"':
+			start := i
+			i++
+			var value strings.Builder
+			for {
+				if i >= len(source) || source[i] == '\n' {
+					return nil, fmt.Errorf("Syntax Error: unterminated "+
+						"string at position %d", start)
+				}
+				if source[i] == '"' {
+					i++
+					break
+				}
+				if source[i] == '\\' && i+1 < len(source) {
+					switch source[i+1] {
+					case 'n':
+						value.WriteByte('\n')
+					case 't':
+						value.WriteByte('\t')
+					case 'r':
+						value.WriteByte('\r')
+					case 'b':
+						value.WriteByte('\b')
+					case 'f':
+						value.WriteByte('\f')
+					case 'u':
+						if i+6 > len(source) {
+							return nil, fmt.Errorf("Syntax Error: invalid "+
+								"escape at position %d", i)
+						}
+						code, err := strconv.ParseUint(source[i+2:i+6], 16, 32)
+						if err != nil {
+							return nil, fmt.Errorf("Syntax Error: invalid "+
+								"escape at position %d", i)
+						}
+						value.WriteRune(rune(code))
+						i += 4
+					default:
+						value.WriteByte(source[i+1])
+					}
+					i += 2
+					continue
+				}
+				r, size := utf8.DecodeRuneInString(source[i:])
+				value.WriteRune(r)
+				i += size
+			}
+			tokens = append(tokens, token{tokenString, value.String(), start})
+		default:
+			return nil, fmt.Errorf("Syntax Error: unexpected character "+
+				"%q at position %d", c, i)
+		}
+	}
+	return append(tokens, token{tokenEOF, "", len(source)}), nil
+}
+
+// maxNesting bounds nesting of selection sets and argument values, so that
+// deeply nested documents are rejected before exhausting stack
+const maxNesting = 64
+
+// parser builds operations from tokens of a query document
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isPunctuator(value string) bool {
+	t := p.peek()
+	return t.kind == tokenPunctuator && t.value == value
+}
+
+func (p *parser) expect(value string) error {
+	t := p.next()
+	if t.kind != tokenPunctuator || t.value != value {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.next()
+	if t.kind != tokenName {
+		return "", p.unexpected(t)
+	}
+	return t.value, nil
+}
+
+// nest enters nested selection set or value, failing past maxNesting
+func (p *parser) nest() error {
+	p.depth++
+	if p.depth > maxNesting {
+		return fmt.Errorf("Syntax Error: nesting exceeds maximum of %d at "+
+			"position %d", maxNesting, p.peek().pos)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("Syntax Error: unexpected end of document")
+	}
+	return fmt.Errorf("Syntax Error: unexpected %q at position %d",
+		t.value, t.pos)
+}
+
+// Parse parses query document into its operations, with fragment spreads
+// replaced by selections of their fragments
+func Parse(source string) ([]*Operation, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	operations := []*Operation{}
+	fragments := map[string]*fragment{}
+	for p.peek().kind != tokenEOF {
+		if t := p.peek(); t.kind == tokenName && t.value == "fragment" {
+			name, fragment, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := fragments[name]; ok {
+				return nil, fmt.Errorf("There can be only one fragment "+
+					"named %q", name)
+			}
+			fragments[name] = fragment
+			continue
+		}
+		operation, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("Syntax Error: document has no operations")
+	}
+
+	spreads := &spreader{fragments: fragments, done: map[string][]*Field{},
+		visiting: map[string]bool{}}
+	for _, operation := range operations {
+		if err = spreads.replace(operation.Selections); err != nil {
+			return nil, err
+		}
+	}
+	return operations, nil
+}
+
+// spreader replaces fragment spreads by selections of their fragments,
+// fragments spread several times share their selections
+type spreader struct {
+	fragments map[string]*fragment
+	done      map[string][]*Field
+	visiting  map[string]bool
+}
+
+// replace replaces fragment spreads in fields and their selections
+func (s *spreader) replace(fields []*Field) error {
+	for _, field := range fields {
+		if len(field.spread) == 0 {
+			if err := s.replace(field.Selections); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fragment, ok := s.fragments[field.spread]
+		if !ok {
+			return fmt.Errorf("Unknown fragment %q", field.spread)
+		}
+		if s.visiting[field.spread] {
+			return fmt.Errorf("Cannot spread fragment %q within itself",
+				field.spread)
+		}
+		if _, ok := s.done[field.spread]; !ok {
+			s.visiting[field.spread] = true
+			if err := s.replace(fragment.selections); err != nil {
+				return err
+			}
+			s.visiting[field.spread] = false
+			s.done[field.spread] = fragment.selections
+		}
+		field.On = fragment.on
+		field.Selections = s.done[field.spread]
+		field.spread = ""
+	}
+	return nil
+}
+
+// fragment parses named fragment definition
+func (p *parser) fragment() (string, *fragment, error) {
+	p.next()
+	name, err := p.name()
+	if err != nil {
+		return "", nil, err
+	}
+	if t := p.next(); t.kind != tokenName || t.value != "on" {
+		return "", nil, p.unexpected(t)
+	}
+	on, err := p.name()
+	if err != nil {
+		return "", nil, err
+	}
+	if p.isPunctuator("@") {
+		return "", nil, fmt.Errorf("directives are not supported")
+	}
+	selections, err := p.selectionSet()
+	if err != nil {
+		return "", nil, err
+	}
+	return name, &fragment{on: on, selections: selections}, nil
+}
+
+// operation parses query operation, shorthand queries included
+func (p *parser) operation() (*Operation, error) {
+	operation := &Operation{Type: "query", Defaults: map[string]interface{}{}}
+
+	if p.peek().kind == tokenName {
+		operation.Type = p.next().value
+		if p.peek().kind == tokenName {
+			operation.Name = p.next().value
+		}
+		if p.isPunctuator("(") {
+			if err := p.variableDefinitions(operation); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.isPunctuator("@") {
+		return nil, fmt.Errorf("directives are not supported")
+	}
+
+	selections, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	operation.Selections = selections
+	return operation, nil
+}
+
+// variableDefinitions parses variables of operation keeping their defaults,
+// types are not checked
+func (p *parser) variableDefinitions(operation *Operation) error {
+	p.next()
+	for !p.isPunctuator(")") {
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if err = p.expect(":"); err != nil {
+			return err
+		}
+		if err = p.skipType(); err != nil {
+			return err
+		}
+		if p.isPunctuator("=") {
+			p.next()
+			value, err := p.value()
+			if err != nil {
+				return err
+			}
+			operation.Defaults[name] = value
+		}
+	}
+	p.next()
+	return nil
+}
+
+// skipType skips type of variable definition
+func (p *parser) skipType() error {
+	if p.isPunctuator("[") {
+		p.next()
+		if err := p.skipType(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	if p.isPunctuator("!") {
+		p.next()
+	}
+	return nil
+}
+
+// selectionSet parses fields and fragments selected between braces
+func (p *parser) selectionSet() ([]*Field, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+	fields := []*Field{}
+	for !p.isPunctuator("}") {
+		var field *Field
+		var err error
+		if p.isPunctuator(fragmentName) {
+			field, err = p.fragmentSelection()
+		} else {
+			field, err = p.field()
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, p.unexpected(p.peek())
+	}
+	p.next()
+	return fields, nil
+}
+
+// fragmentSelection parses fragment spread, or inline fragment with
+// optional type condition
+func (p *parser) fragmentSelection() (*Field, error) {
+	p.next()
+	field := &Field{Name: fragmentName}
+	if t := p.peek(); t.kind == tokenName && t.value != "on" {
+		field.spread = p.next().value
+	} else {
+		if t.kind == tokenName {
+			p.next()
+			on, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			field.On = on
+		}
+		if !p.isPunctuator("@") {
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			field.Selections = selections
+		}
+	}
+	if p.isPunctuator("@") {
+		return nil, fmt.Errorf("directives are not supported")
+	}
+	return field, nil
+}
+
+// field parses field with its alias, arguments and selections
+func (p *parser) field() (*Field, error) {
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	field := &Field{Name: name, Arguments: map[string]interface{}{}}
+
+	if p.isPunctuator(":") {
+		p.next()
+		field.Alias = field.Name
+		if field.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isPunctuator("(") {
+		p.next()
+		for !p.isPunctuator(")") {
+			argument, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(":"); err != nil {
+				return nil, err
+			}
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			field.Arguments[argument] = value
+		}
+		p.next()
+	}
+
+	if p.isPunctuator("@") {
+		return nil, fmt.Errorf("directives are not supported")
+	}
+
+	if p.isPunctuator("{") {
+		if field.Selections, err = p.selectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+// value parses argument value
+func (p *parser) value() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenInt:
+		return strconv.ParseInt(t.value, 10, 64)
+	case tokenFloat:
+		return strconv.ParseFloat(t.value, 64)
+	case tokenString:
+		return t.value, nil
+	case tokenName:
+		switch t.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return t.value, nil
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			name, err := p.name()
+			return variable(name), err
+		case "[":
+			if err := p.nest(); err != nil {
+				return nil, err
+			}
+			defer func() { p.depth-- }()
+			list := []interface{}{}
+			for !p.isPunctuator("]") {
+				value, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, value)
+			}
+			p.next()
+			return list, nil
+		case "{":
+			if err := p.nest(); err != nil {
+				return nil, err
+			}
+			defer func() { p.depth-- }()
+			object := map[string]interface{}{}
+			for !p.isPunctuator("}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err = p.expect(":"); err != nil {
+					return nil, err
+				}
+				if object[name], err = p.value(); err != nil {
+					return nil, err
+				}
+			}
+			p.next()
+			return object, nil
+		}
+	}
+	return nil, p.unexpected(t)
+}
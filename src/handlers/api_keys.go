@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+)
+
+// APIKeyHeader is the request header carrying API key of request
+const APIKeyHeader = "X-API-Key"
+
+// ScopeWrite is the scope required by routes that submit transactions, in
+// addition to scope of their group
+const ScopeWrite = "write"
+
+// scopeAll grants every scope, or access to every node
+const scopeAll = "*"
+
+// APIKey is a key allowed to call the API, only the SHA-256 hash of the key
+// is kept
+type APIKey struct {
+	Name   string
+	Hash   [sha256.Size]byte
+	Scopes map[string]bool
+	Nodes  map[string]bool
+
+	// RateLimit of requests made with key, default rate limit if rate is 0
+	RateLimit RateLimit
+}
+
+// apiKeyContext is the context key of API key that authorized request,
+// internal requests made through router carry it in their context
+type apiKeyContext struct{}
+
+// pinningContext marks internal requests retrieving latest height on behalf
+// of a route, they need no scope beyond the scopes of that route
+type pinningContext struct{}
+
+// API keys allowed to call the API, API keys aren't required if empty
+var (
+	apiKeysMutex sync.RWMutex
+	apiKeys      []*APIKey
+)
+
+// Scopes required by each route, built from route table on first use
+var (
+	routeScopesOnce sync.Once
+	routeScopes     map[string][]string
+)
+
+// pinningRequest returns context of internal request retrieving latest
+// height on behalf of route answering request with context ctx
+func pinningRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pinningContext{}, true)
+}
+
+// splitList returns trimmed non-empty items of comma separated list
+func splitList(list string) map[string]bool {
+	items := map[string]bool{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items[item] = true
+		}
+	}
+	return items
+}
+
+// LoadAPIKeys returns API keys of keys configuration, each entry holds name
+// of key, hex encoded SHA-256 hash of key, and comma separated lists of
+// scopes and nodes the key may access. Entries may set their own rate limit.
+func LoadAPIKeys(conf map[string]map[string]string) ([]*APIKey, error) {
+	keys := []*APIKey{}
+	for section, entry := range conf {
+		key := &APIKey{
+			Name:   entry["key_name"],
+			Scopes: splitList(entry["scopes"]),
+			Nodes:  splitList(entry["nodes"]),
+		}
+		if len(key.Name) == 0 {
+			return nil, errors.New("key_name of " + section + " is missing")
+		}
+
+		hash, err := hex.DecodeString(strings.TrimSpace(entry["key_hash"]))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.New("key_hash of " + section + " needs to " +
+				"be a hex encoded SHA-256 hash")
+		}
+		copy(key.Hash[:], hash)
+
+		if len(key.Scopes) == 0 || len(key.Nodes) == 0 {
+			return nil, errors.New("scopes and nodes of " + section +
+				" need to be given")
+		}
+
+		if len(entry["rate_limit"]) > 0 {
+			limit, err := ParseRateLimit(entry["rate_limit"],
+				entry["rate_burst"])
+			if err != nil {
+				return nil, errors.New("rate_limit and rate_burst of " +
+					section + " need to be positive numbers")
+			}
+			key.RateLimit = limit
+		}
+		keys = append(keys, key)
+	}
+
+	// Sort keys so that they're checked in the same order every time
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys, nil
+}
+
+// SetAPIKeys sets API keys allowed to call the API, requests need no key if
+// no keys are set.
+func SetAPIKeys(keys []*APIKey) {
+	apiKeysMutex.Lock()
+	defer apiKeysMutex.Unlock()
+	apiKeys = keys
+}
+
+// APIKeysRequired returns whether requests need an API key, responses then
+// depend on key of request
+func APIKeysRequired() bool {
+	apiKeysMutex.RLock()
+	defer apiKeysMutex.RUnlock()
+	return len(apiKeys) > 0
+}
+
+// findAPIKey returns API key with hash of given key, comparing every key in
+// constant time
+func findAPIKey(keys []*APIKey, given string) *APIKey {
+	hash := sha256.Sum256([]byte(given))
+	var found *APIKey
+	for _, key := range keys {
+		if subtle.ConstantTimeCompare(hash[:], key.Hash[:]) == 1 {
+			found = key
+		}
+	}
+	return found
+}
+
+// requiredScopes returns scopes required to call route at path
+func requiredScopes(path string) ([]string, bool) {
+	routeScopesOnce.Do(func() {
+		routeScopes = map[string][]string{}
+		for _, route := range Routes() {
+			scopes := route.Scopes
+			if len(scopes) == 0 {
+				scopes = []string{route.Group}
+			}
+			routeScopes[route.Path] = scopes
+		}
+	})
+	scopes, ok := routeScopes[path]
+	return scopes, ok
+}
+
+// allowsScopes returns whether key holds all scopes
+func (key *APIKey) allowsScopes(scopes []string) bool {
+	if key.Scopes[scopeAll] {
+		return true
+	}
+	for _, scope := range scopes {
+		if !key.Scopes[scope] {
+			return false
+		}
+	}
+	return true
+}
+
+// allowsNode returns whether key may query node, keys allowed a node group
+// may query each of its members
+func (key *APIKey) allowsNode(name string) bool {
+	if len(name) == 0 || key.Nodes[scopeAll] || key.Nodes[name] {
+		return true
+	}
+	for group, members := range nodeGroups() {
+		if !key.Nodes[group] {
+			continue
+		}
+		for _, member := range members {
+			if member == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requestAllowsNode returns whether API key of request may query node,
+// routes reading every configured node leave out nodes key may not query
+func requestAllowsNode(r *http.Request, name string) bool {
+	key, ok := r.Context().Value(apiKeyContext{}).(*APIKey)
+	return !ok || key.allowsNode(name)
+}
+
+// denyRequest answers request with status and error message
+func denyRequest(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(responses.ErrorResponse{Error: message})
+}
+
+// APIKeys requires requests to carry a configured API key in the X-API-Key
+// header, whose scopes allow the group of route and node queried. Every use
+// of a key is logged for auditing. Requests need no key if no keys are set.
+func APIKeys(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeysMutex.RLock()
+		keys := apiKeys
+		apiKeysMutex.RUnlock()
+		if len(keys) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				path = template
+			}
+		}
+
+		// Requests made internally by routes carry key of outer request
+		key, internal := r.Context().Value(apiKeyContext{}).(*APIKey)
+		if !internal {
+			key = findAPIKey(keys, r.Header.Get(APIKeyHeader))
+		}
+		if key == nil {
+			lgr.Warning.Printf("API key audit : denied %s %s from %s, "+
+				"missing or invalid API key", r.Method, path, r.RemoteAddr)
+			denyRequest(w, http.StatusUnauthorized,
+				"Missing or invalid API key!")
+			return
+		}
+
+		// Nodes are named by name parameter in v1 and node parameter in v2
+		query := r.URL.Query()
+		node := query.Get("name")
+		if strings.HasPrefix(path, "/api/v2/") {
+			node = query.Get("node")
+		}
+
+		origin := r.RemoteAddr
+		if internal {
+			origin = "internal request"
+		}
+
+		scopes, ok := requiredScopes(path)
+		pinning, _ := r.Context().Value(pinningContext{}).(bool)
+		if !(internal && pinning) && (!ok || !key.allowsScopes(scopes)) {
+			lgr.Warning.Printf("API key audit : key %s denied %s %s node "+
+				"%q from %s, scope %s required", key.Name, r.Method, path,
+				node, origin, strings.Join(scopes, ","))
+			denyRequest(w, http.StatusForbidden, "API key is not allowed "+
+				"to access this route!")
+			return
+		}
+		if !key.allowsNode(node) {
+			lgr.Warning.Printf("API key audit : key %s denied %s %s node "+
+				"%q from %s, node not allowed", key.Name, r.Method, path,
+				node, origin)
+			denyRequest(w, http.StatusForbidden, "API key is not allowed "+
+				"to access node "+node+"!")
+			return
+		}
+
+		lgr.Info.Printf("API key audit : key %s allowed %s %s node %q "+
+			"from %s", key.Name, r.Method, path, node, origin)
+		ctx := context.WithValue(r.Context(), apiKeyContext{}, key)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
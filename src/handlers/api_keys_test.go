@@ -0,0 +1,193 @@
+package handlers_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+// apiKeysRouter returns router requiring API key with staking scope on
+// node Oasis_Local, routes answer with ok
+func apiKeysRouter(t *testing.T) http.Handler {
+	hash := sha256.Sum256([]byte("secret"))
+	keys, err := hdl.LoadAPIKeys(map[string]map[string]string{
+		"key_0": {
+			"key_name": "monitoring",
+			"key_hash": hex.EncodeToString(hash[:]),
+			"scopes":   "staking, governance",
+			"nodes":    "Oasis_Local",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load API keys : %v", err)
+	}
+	hdl.SetAPIKeys(keys)
+
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}
+	router := mux.NewRouter()
+	router.Use(hdl.APIKeys)
+	router.HandleFunc("/api/ping", ok)
+	router.HandleFunc("/api/staking/accountinfo", ok)
+	router.HandleFunc("/api/governance/castvote", ok)
+	return router
+}
+
+// serveWithKey serves request at path with query name and API key
+func serveWithKey(router http.Handler, path string, name string,
+	key string) *httptest.ResponseRecorder {
+
+	req, _ := http.NewRequest("GET", path, nil)
+	q := req.URL.Query()
+	q.Add("name", name)
+	req.URL.RawQuery = q.Encode()
+	if len(key) > 0 {
+		req.Header.Set(hdl.APIKeyHeader, key)
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func Test_APIKeys_Allowed(t *testing.T) {
+	router := apiKeysRouter(t)
+	defer hdl.SetAPIKeys(nil)
+
+	rr := serveWithKey(router, "/api/staking/accountinfo", "Oasis_Local",
+		"secret")
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if rr.Body.String() != "ok" {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), "ok")
+	}
+}
+
+func Test_APIKeys_Missing(t *testing.T) {
+	router := apiKeysRouter(t)
+	defer hdl.SetAPIKeys(nil)
+
+	for _, key := range []string{"", "wrong"} {
+		rr := serveWithKey(router, "/api/staking/accountinfo",
+			"Oasis_Local", key)
+		if status := rr.Code; status != http.StatusUnauthorized {
+			t.Errorf("handler returned wrong status code: got %v want %v",
+				status, http.StatusUnauthorized)
+		}
+
+		expected := `{"error":"Missing or invalid API key!"}`
+		if strings.TrimSpace(rr.Body.String()) != expected {
+			t.Errorf("handler returned unexpected body: got %v want %v",
+				rr.Body.String(), expected)
+		}
+	}
+}
+
+func Test_APIKeys_Forbidden(t *testing.T) {
+	router := apiKeysRouter(t)
+	defer hdl.SetAPIKeys(nil)
+
+	tests := []struct {
+		path     string
+		name     string
+		expected string
+	}{
+		{"/api/ping", "", `{"error":"API key is not allowed to access ` +
+			`this route!"}`},
+		{"/api/governance/castvote", "Oasis_Local", `{"error":"API key is ` +
+			`not allowed to access this route!"}`},
+		{"/api/staking/accountinfo", "Oasis_Other", `{"error":"API key is ` +
+			`not allowed to access node Oasis_Other!"}`},
+	}
+	for _, test := range tests {
+		rr := serveWithKey(router, test.path, test.name, "secret")
+		if status := rr.Code; status != http.StatusForbidden {
+			t.Errorf("handler returned wrong status code for %s: got %v "+
+				"want %v", test.path, status, http.StatusForbidden)
+		}
+		if strings.TrimSpace(rr.Body.String()) != test.expected {
+			t.Errorf("handler returned unexpected body: got %v want %v",
+				rr.Body.String(), test.expected)
+		}
+	}
+}
+
+func Test_APIKeys_InvalidHash(t *testing.T) {
+	_, err := hdl.LoadAPIKeys(map[string]map[string]string{
+		"key_0": {
+			"key_name": "monitoring",
+			"key_hash": "secret",
+			"scopes":   "*",
+			"nodes":    "*",
+		},
+	})
+	if err == nil {
+		t.Errorf("LoadAPIKeys accepted key_hash that isn't a SHA-256 hash")
+	}
+}
+
+func Test_APIKeys_AllNodeRoutes(t *testing.T) {
+	hash := sha256.Sum256([]byte("local"))
+	keys, err := hdl.LoadAPIKeys(map[string]map[string]string{
+		"key_0": {
+			"key_name": "local",
+			"key_hash": hex.EncodeToString(hash[:]),
+			"scopes":   "general, nodecontroller",
+			"nodes":    "Oasis_Local",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load API keys : %v", err)
+	}
+	hdl.SetAPIKeys(keys)
+	defer hdl.SetAPIKeys(nil)
+
+	router := mux.NewRouter()
+	router.Use(hdl.APIKeys)
+	router.HandleFunc("/api/getconnectionslist", hdl.GetConnections)
+	router.HandleFunc("/api/consistency", hdl.GetConsistency)
+	router.HandleFunc("/api/nodecontroller/waitready", hdl.WaitReady)
+
+	// Routes reading every node leave out nodes key may not query
+	rr := serveWithKey(router, "/api/getconnectionslist", "", "local")
+	expected := `{"result":["Oasis_Local"]}`
+	if strings.TrimSpace(rr.Body.String()) != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+
+	rr = serveWithKey(router, "/api/consistency", "", "local")
+	if !strings.Contains(rr.Body.String(), `"node_name":"Oasis_Local"`) ||
+		strings.Contains(rr.Body.String(), `"node_name":"Oasis_Local_`) {
+		t.Errorf("handler returned unexpected body: got %v want only "+
+			"node Oasis_Local", rr.Body.String())
+	}
+
+	// Reference nodes need to be allowed too
+	req, _ := http.NewRequest("GET", "/api/nodecontroller/waitready"+
+		"?name=Oasis_Local&reference=Oasis_Local_1", nil)
+	req.Header.Set(hdl.APIKeyHeader, "local")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusForbidden {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusForbidden)
+	}
+	expected = `{"error":"API key is not allowed to access node ` +
+		`Oasis_Local_1!"}`
+	if strings.TrimSpace(rr.Body.String()) != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+)
+
+// Maximum number of sub-requests accepted in a single batch
+const maxBatchRequests = 100
+
+// Number of sub-requests of a batch executed at the same time
+const batchWorkers = 8
+
+// StreamingPaths holds endpoints that stream responses, these can't be
+// cached or batched
+var StreamingPaths = map[string]bool{
+	"/api/beacon/watchepochs":       true,
+	"/api/nodecontroller/waitready": true,
+}
+
+// BatchRequest is a single sub-request of a batch
+type BatchRequest struct {
+	Path  string            `json:"path"`
+	Query map[string]string `json:"query"`
+}
+
+// batchRecorder captures response of sub-request
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *batchRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *batchRecorder) Write(b []byte) (int, error) {
+	return rec.body.Write(b)
+}
+
+func (rec *batchRecorder) WriteHeader(status int) {
+	rec.status = status
+}
+
+// internalContext marks requests made by routes through router
+type internalContext struct{}
+
+// serveInternal runs request for path with given method and query through
+// router and returns recorded response
+func serveInternal(ctx context.Context, router http.Handler, method string,
+	path string, query url.Values) (*batchRecorder, error) {
+
+	req, err := http.NewRequest(method, path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	rec := &batchRecorder{header: http.Header{}, status: http.StatusOK}
+	ctx = context.WithValue(ctx, internalContext{}, true)
+	router.ServeHTTP(rec, req.WithContext(ctx))
+	return rec, nil
+}
+
+// Routes taking height parameter, built from route table on first use
+var (
+	heightRoutesOnce sync.Once
+	heightRoutes     map[string]bool
+)
+
+// takesHeight returns whether route at path declares height parameter
+func takesHeight(path string) bool {
+	heightRoutesOnce.Do(func() {
+		heightRoutes = map[string]bool{}
+		for _, route := range Routes() {
+			for _, param := range route.Parameters {
+				if param.Name == paramHeight.Name {
+					heightRoutes[route.Path] = true
+				}
+			}
+		}
+	})
+	return heightRoutes[path]
+}
+
+// pinned returns whether sub-request is pinned to height shared by batch
+func (request *BatchRequest) pinned() bool {
+	return takesHeight(request.Path) && len(request.Query["name"]) > 0 &&
+		len(request.Query["height"]) == 0
+}
+
+// pinLatestHeight returns latest height reached by all nodes queried at
+// latest height in batch, or 0 if it can't be retrieved, together with node
+// that answered for each node or node group. Heights are retrieved through
+// router so that groups are resolved and nodes the API key may not query
+// are never reached.
+func pinLatestHeight(ctx context.Context, router http.Handler,
+	requests []*BatchRequest) (int64, map[string]string) {
+
+	var pinned int64
+	backends := map[string]string{}
+	checked := map[string]bool{}
+	for _, request := range requests {
+		name := request.Query["name"]
+		if !request.pinned() || checked[name] {
+			continue
+		}
+		checked[name] = true
+
+		height, backend, message := v2LatestHeight(ctx, router, name)
+		if len(message) > 0 {
+			continue
+		}
+		if len(backend) > 0 {
+			backends[name] = backend
+		}
+		if pinned == 0 || height < pinned {
+			pinned = height
+		}
+	}
+	return pinned, backends
+}
+
+// executeBatchRequest runs sub-request through router and returns its
+// result, queries of node groups at latest height are answered by node that
+// answered pinned height
+func executeBatchRequest(ctx context.Context, router http.Handler,
+	request *BatchRequest, height int64,
+	backends map[string]string) *responses.BatchResult {
+
+	result := &responses.BatchResult{Path: request.Path}
+	if request.Path == "/api/batch" || StreamingPaths[request.Path] {
+		result.Error = "Path can't be batched!"
+		return result
+	}
+
+	// Pin queries at latest height to height shared by whole batch
+	query := url.Values{}
+	for key, value := range request.Query {
+		query.Set(key, value)
+	}
+	if height > 0 && request.pinned() {
+		query.Set("height", strconv.FormatInt(height, 10))
+		if backend, ok := backends[query.Get("name")]; ok {
+			query.Set("name", backend)
+		}
+	}
+
+	rec, err := serveInternal(ctx, router, http.MethodGet, request.Path,
+		query)
+	if err != nil {
+		result.Error = "Invalid path!"
+		return result
+	}
+
+	if rec.status != http.StatusOK || !json.Valid(rec.body.Bytes()) {
+		result.Error = http.StatusText(rec.status)
+		if rec.status == http.StatusOK {
+			result.Error = "Invalid response!"
+		}
+		return result
+	}
+	result.Response = json.RawMessage(rec.body.Bytes())
+	return result
+}
+
+// Batch returns handler executing an array of sub-requests against router
+// concurrently, with all queries at latest height pinned to the same height.
+func Batch(router http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Add header so that received knows they're receiving JSON
+		w.Header().Add("Content-Type", "application/json")
+
+		// Decode sub-requests from request body
+		requests := []*BatchRequest{}
+		err := json.NewDecoder(r.Body).Decode(&requests)
+		if err != nil {
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Unexpected value found, body needs to be " +
+					"an array of requests!"})
+			return
+		}
+		if len(requests) > maxBatchRequests {
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Unexpected value found, batch can contain " +
+					"up to 100 requests!"})
+			return
+		}
+		for _, request := range requests {
+			if request == nil {
+				json.NewEncoder(w).Encode(responses.ErrorResponse{
+					Error: "Unexpected value found, body needs to " +
+						"be an array of requests!"})
+				return
+			}
+		}
+
+		height, backends := pinLatestHeight(r.Context(), router, requests)
+
+		// Execute sub-requests with bounded number of workers
+		results := make([]*responses.BatchResult, len(requests))
+		indices := make(chan int)
+		var wg sync.WaitGroup
+		for i := 0; i < batchWorkers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for index := range indices {
+					results[index] = executeBatchRequest(r.Context(),
+						router, requests[index], height, backends)
+				}
+			}()
+		}
+		for index := range requests {
+			indices <- index
+		}
+		close(indices)
+		wg.Wait()
+
+		// Responding with results in order of sub-requests
+		lgr.Info.Println("Request at /api/batch responding with " +
+			"Batch Results!")
+		json.NewEncoder(w).Encode(responses.BatchResponse{
+			Batch: &responses.Batch{
+				Height:  height,
+				Results: results,
+			}})
+	}
+}
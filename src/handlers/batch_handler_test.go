@@ -0,0 +1,137 @@
+package handlers_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+func Test_Batch_InvalidBody(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/api/batch",
+		strings.NewReader(`{"path":"/api/ping"}`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.Batch(http.HandlerFunc(hdl.Pong))
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+
+	expected := `{"error":"Unexpected value found, body needs to be an array of requests!"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_Batch_Success(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/api/batch",
+		strings.NewReader(`[{"path":"/api/ping"},{"path":"/api/batch"}]`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.Batch(http.HandlerFunc(hdl.Pong))
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+
+	expected := `{"result":{"results":[{"path":"/api/ping","response":{"result":"pong"}},{"path":"/api/batch","error":"Path can't be batched!"}]}}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+// batchRouter answers latest height of every node with 100 served by node
+// Oasis_Member, and echoes queries of other routes
+func batchRouter(heights *int) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/api/consensus/height",
+		func(w http.ResponseWriter, r *http.Request) {
+			*heights++
+			w.Header().Set(hdl.BackendHeader, "Oasis_Member")
+			w.Write([]byte(`{"result":100}`))
+		})
+	router.HandleFunc("/api/consensus/block", echoQuery)
+	router.HandleFunc("/api/prometheus/gauge", echoQuery)
+	return router
+}
+
+func Test_Batch_PinnedRoutes(t *testing.T) {
+	heights := 0
+	router := batchRouter(&heights)
+
+	req, _ := http.NewRequest("POST", "/api/batch", strings.NewReader(
+		`[{"path":"/api/consensus/block","query":{"name":"Oasis_Group"}},`+
+			`{"path":"/api/prometheus/gauge","query":{"name":"Oasis_Local"}}]`))
+
+	rr := httptest.NewRecorder()
+	hdl.Batch(router).ServeHTTP(rr, req)
+
+	expected := `{"result":{"height":100,"results":[` +
+		`{"path":"/api/consensus/block","response":{"result":` +
+		`{"height":["100"],"name":["Oasis_Member"]}}},` +
+		`{"path":"/api/prometheus/gauge","response":{"result":` +
+		`{"name":["Oasis_Local"]}}}]}}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+	if heights != 1 {
+		t.Errorf("Expected latest height to be retrieved once got %v",
+			heights)
+	}
+}
+
+func Test_Batch_NodeNotAllowed(t *testing.T) {
+	hash := sha256.Sum256([]byte("secret"))
+	keys, err := hdl.LoadAPIKeys(map[string]map[string]string{
+		"key_0": {
+			"key_name": "explorer",
+			"key_hash": hex.EncodeToString(hash[:]),
+			"scopes":   "general, consensus",
+			"nodes":    "Oasis_Local",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to load API keys : %v", err)
+	}
+	hdl.SetAPIKeys(keys)
+	defer hdl.SetAPIKeys(nil)
+
+	heights := 0
+	router := batchRouter(&heights)
+	router.Use(hdl.APIKeys)
+
+	req, _ := http.NewRequest("POST", "/api/batch", strings.NewReader(
+		`[{"path":"/api/consensus/block","query":{"name":"Oasis_Other"}}]`))
+	req.Header.Set(hdl.APIKeyHeader, "secret")
+
+	rr := httptest.NewRecorder()
+	router.Handle("/api/batch", hdl.Batch(router))
+	router.ServeHTTP(rr, req)
+
+	expected := `{"result":{"results":[{"path":"/api/consensus/block",` +
+		`"error":"Forbidden"}]}}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+	if heights != 0 {
+		t.Errorf("Expected node the API key may not query not to be " +
+			"reached")
+	}
+}
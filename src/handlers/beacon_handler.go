@@ -0,0 +1,597 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// Number of blocks over which average block time is observed
+const blockTimeWindow = 100
+
+// Maximum number of epoch transitions that can be projected at once
+const maxEpochTransitions = 100
+
+// errEpochOutOfRange is returned for epochs whose start height or time is
+// too far in the future to be represented
+var errEpochOutOfRange = errors.New("epoch is too far in the future")
+
+// epochInterval returns epoch interval in blocks from beacon parameters.
+// PVSS epochs last for a commit and reveal phase followed by a transition
+// delay, assuming rounds do not fail.
+func epochInterval(params *beacon.ConsensusParameters) int64 {
+	switch {
+	case params.InsecureParameters != nil:
+		return params.InsecureParameters.Interval
+	case params.PVSSParameters != nil:
+		return params.PVSSParameters.CommitInterval +
+			params.PVSSParameters.RevealInterval +
+			params.PVSSParameters.TransitionDelay
+	default:
+		return 0
+	}
+}
+
+// estimateEpochTiming retrieves current epoch, its start height, epoch
+// interval and average block time observed over recent blocks.
+func estimateEpochTiming(co consensus.ClientBackend,
+	be beacon.Backend) (*responses.EpochTiming, error) {
+
+	ctx := context.Background()
+
+	// Retrieve latest block height and time
+	status, err := co.GetStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := be.GetEpoch(ctx, status.LatestHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	startHeight, err := be.GetEpochBlock(ctx, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := be.ConsensusParameters(ctx, status.LatestHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// Observe block time over window of blocks that are still retained
+	pastHeight := status.LatestHeight - blockTimeWindow
+	if pastHeight < status.LastRetainedHeight {
+		pastHeight = status.LastRetainedHeight
+	}
+	if pastHeight < 1 {
+		pastHeight = 1
+	}
+
+	var averageBlockTime float64
+	if pastHeight < status.LatestHeight {
+		pastBlock, err := co.GetBlock(ctx, pastHeight)
+		if err != nil {
+			return nil, err
+		}
+		averageBlockTime = status.LatestTime.Sub(pastBlock.Time).Seconds() /
+			float64(status.LatestHeight-pastHeight)
+	}
+
+	return &responses.EpochTiming{
+		Epoch:            epoch,
+		StartHeight:      startHeight,
+		Interval:         epochInterval(params),
+		LatestHeight:     status.LatestHeight,
+		LatestTime:       status.LatestTime,
+		AverageBlockTime: averageBlockTime,
+	}, nil
+}
+
+// projectEpoch estimates height and time at which given future epoch
+// starts, based on observed epoch timing.
+func projectEpoch(timing *responses.EpochTiming,
+	epoch beacon.EpochTime) (*responses.EpochTransition, error) {
+
+	epochs := uint64(epoch - timing.Epoch)
+	if timing.Interval > 0 && epochs > uint64(
+		(math.MaxInt64-timing.StartHeight)/timing.Interval) {
+		return nil, errEpochOutOfRange
+	}
+	height := timing.StartHeight + int64(epochs)*timing.Interval
+
+	wait := float64(height-timing.LatestHeight) * timing.AverageBlockTime *
+		float64(time.Second)
+	if wait >= math.MaxInt64 {
+		return nil, errEpochOutOfRange
+	}
+
+	return &responses.EpochTransition{
+		Epoch:     epoch,
+		Height:    height,
+		Time:      timing.LatestTime.Add(time.Duration(wait)),
+		Estimated: true,
+	}, nil
+}
+
+// epochTransition returns height and time at which given epoch starts,
+// actual values are used for epochs that have already started.
+func epochTransition(co consensus.ClientBackend, be beacon.Backend,
+	timing *responses.EpochTiming,
+	epoch beacon.EpochTime) (*responses.EpochTransition, error) {
+
+	if epoch > timing.Epoch {
+		return projectEpoch(timing, epoch)
+	}
+
+	height, err := be.GetEpochBlock(context.Background(), epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := co.GetBlock(context.Background(), height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.EpochTransition{
+		Epoch:  epoch,
+		Height: height,
+		Time:   blk.Time,
+	}, nil
+}
+
+// GetEpochTiming returns current epoch with its start height, epoch interval,
+// observed average block time and projected next epoch transitions.
+func GetEpochTiming(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving number of transitions to project from query request
+	recvCount := r.URL.Query().Get("count")
+	count := checkAmount(recvCount)
+	if count < 0 || count > maxEpochTransitions {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, count needs to be " +
+				"a string representing an int up to 100!"})
+		return
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve epoch timing observed at latest height
+	timing, err := estimateEpochTiming(co, be)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Timing!"})
+		lgr.Error.Println("Request at /api/consensus/epochtiming failed "+
+			"to retrieve Epoch Timing : ", err)
+		return
+	}
+
+	// Project upcoming epoch transitions
+	timing.Transitions = []*responses.EpochTransition{}
+	for i := int64(1); i <= count; i++ {
+		transition, err := projectEpoch(timing,
+			timing.Epoch+beacon.EpochTime(i))
+		if err != nil {
+			break
+		}
+		timing.Transitions = append(timing.Transitions, transition)
+	}
+
+	// Responding with epoch timing retrieved above
+	lgr.Info.Println("Request at /api/consensus/epochtiming responding " +
+		"with Epoch Timing!")
+	json.NewEncoder(w).Encode(responses.EpochTimingResponse{
+		Timing: timing})
+}
+
+// GetEpochDate converts an epoch into the height and date at which it starts,
+// estimating both for epochs that have not started yet.
+func GetEpochDate(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving epoch from query request
+	recvEpoch := r.URL.Query().Get("epoch")
+	epoch, err := strconv.ParseUint(recvEpoch, 10, 64)
+	if err != nil {
+
+		// Stop code here no need to establish connection and reply
+		lgr.Error.Println("Unexpected value found, required "+
+			"string of int but received ", recvEpoch)
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, epoch needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve epoch timing observed at latest height
+	timing, err := estimateEpochTiming(co, be)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Timing!"})
+		lgr.Error.Println("Request at /api/consensus/epochdate failed "+
+			"to retrieve Epoch Timing : ", err)
+		return
+	}
+
+	// Retrieve actual or estimated start of requested epoch
+	transition, err := epochTransition(co, be, timing,
+		beacon.EpochTime(epoch))
+	if err == errEpochOutOfRange {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, epoch is too far in the " +
+				"future to be estimated!"})
+		return
+	}
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Date!"})
+		lgr.Error.Println("Request at /api/consensus/epochdate failed "+
+			"to retrieve Epoch Date : ", err)
+		return
+	}
+
+	// Responding with epoch start retrieved above
+	lgr.Info.Println("Request at /api/consensus/epochdate responding " +
+		"with Epoch Date!")
+	json.NewEncoder(w).Encode(responses.EpochTransitionResponse{
+		Transition: transition})
+}
+
+// GetBeaconState returns state of random beacon at specified block height
+// together with beacon consensus parameters and PVSS round state if beacon
+// is running a PVSS round.
+func GetBeaconState(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, height needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve epoch of specific block height
+	epoch, err := be.GetEpoch(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch of Block!"})
+		lgr.Error.Println("Request at /api/beacon/state failed to "+
+			"retrieve Epoch : ", err)
+		return
+	}
+
+	// Retrieve base epoch of chain
+	baseEpoch, err := be.GetBaseEpoch(context.Background())
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Base Epoch!"})
+		lgr.Error.Println("Request at /api/beacon/state failed to "+
+			"retrieve Base Epoch : ", err)
+		return
+	}
+
+	// Retrieve epoch transition scheduled at specific block height if any
+	futureEpoch, err := be.GetFutureEpoch(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Future Epoch!"})
+		lgr.Error.Println("Request at /api/beacon/state failed to "+
+			"retrieve Future Epoch : ", err)
+		return
+	}
+
+	// Retrieve random beacon at specific block height
+	beaconValue, err := be.GetBeacon(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Beacon!"})
+		lgr.Error.Println("Request at /api/beacon/state failed to "+
+			"retrieve Beacon : ", err)
+		return
+	}
+
+	// Retrieve beacon consensus parameters at specific block height
+	params, err := be.ConsensusParameters(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Beacon Consensus Parameters!"})
+		lgr.Error.Println("Request at /api/beacon/state failed to "+
+			"retrieve Beacon Consensus Parameters : ", err)
+		return
+	}
+
+	// PVSS round state only exists when beacon uses PVSS backend
+	var pvssState *beacon.PVSSState
+	if params.Backend == beacon.BackendPVSS {
+		pvssState, err = rpc.PVSSState(context.Background(), co, height)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to retrieve PVSS State!"})
+			lgr.Error.Println("Request at /api/beacon/state failed to "+
+				"retrieve PVSS State : ", err)
+			return
+		}
+	}
+
+	// Responding with beacon state retrieved above
+	lgr.Info.Println("Request at /api/beacon/state responding with " +
+		"Beacon State!")
+	json.NewEncoder(w).Encode(responses.BeaconStateResponse{
+		BeaconState: &responses.BeaconState{
+			Epoch:       epoch,
+			BaseEpoch:   baseEpoch,
+			FutureEpoch: futureEpoch,
+			Beacon:      beaconValue,
+			Parameters:  params,
+			PVSSState:   pvssState,
+		}})
+}
+
+// WatchEpochs streams epoch transitions as they happen, one JSON object per
+// line, until client disconnects or optional timeout in seconds expires.
+func WatchEpochs(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving timeout in seconds from query request
+	recvTimeout := r.URL.Query().Get("timeout")
+	timeout := checkAmount(recvTimeout)
+	if timeout == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, timeout needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Stream is stopped once client goes away or timeout expires
+	ctx := r.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx,
+			time.Duration(timeout)*time.Second)
+		defer cancel()
+	}
+
+	// Attempt to load connection with beacon client
+	connection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Subscribe to epoch transitions, current epoch is sent immediately
+	epochs, sub, err := be.WatchEpochs(ctx)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to watch Epochs!"})
+		lgr.Error.Println("Request at /api/beacon/watchepochs failed to "+
+			"watch Epochs : ", err)
+		return
+	}
+	defer sub.Close()
+
+	lgr.Info.Println("Request at /api/beacon/watchepochs streaming " +
+		"Epochs!")
+	for {
+		select {
+		case epoch, ok := <-epochs:
+			if !ok {
+				return
+			}
+			streamJSON(w, responses.EpochResponse{Ep: epoch})
+		case <-ctx.Done():
+			lgr.Info.Println("Request at /api/beacon/watchepochs " +
+				"stopped streaming Epochs!")
+			return
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+)
+
+func Test_ProjectEpoch(t *testing.T) {
+	latest := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+	timing := &responses.EpochTiming{
+		Epoch:            10,
+		StartHeight:      1000,
+		Interval:         600,
+		LatestHeight:     1100,
+		LatestTime:       latest,
+		AverageBlockTime: 6,
+	}
+
+	tests := []struct {
+		name   string
+		epoch  beacon.EpochTime
+		height int64
+		time   time.Time
+		err    error
+	}{
+		{"NextEpoch", 11, 1600, latest.Add(500 * 6 * time.Second), nil},
+		{"LaterEpoch", 15, 4000, latest.Add(2900 * 6 * time.Second), nil},
+		{"HeightOverflow", beacon.EpochTime(math.MaxUint64), 0,
+			time.Time{}, errEpochOutOfRange},
+		{"TimeOverflow", 10 + math.MaxInt64/600/2, 0, time.Time{},
+			errEpochOutOfRange},
+	}
+	for _, test := range tests {
+		transition, err := projectEpoch(timing, test.epoch)
+		if err != test.err {
+			t.Errorf("%s: projectEpoch returned error %v want %v",
+				test.name, err, test.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if transition.Epoch != test.epoch ||
+			transition.Height != test.height ||
+			!transition.Time.Equal(test.time) || !transition.Estimated {
+			t.Errorf("%s: projectEpoch returned %+v want height %d at %v",
+				test.name, transition, test.height, test.time)
+		}
+	}
+}
+
+func Test_ProjectEpoch_NoBlockTime(t *testing.T) {
+	latest := time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)
+	timing := &responses.EpochTiming{
+		Epoch:        3,
+		StartHeight:  20,
+		Interval:     10,
+		LatestHeight: 25,
+		LatestTime:   latest,
+	}
+
+	// Without observed block time, transitions are estimated at latest time
+	transition, err := projectEpoch(timing, 5)
+	if err != nil {
+		t.Fatalf("projectEpoch returned error %v", err)
+	}
+	if transition.Height != 40 || !transition.Time.Equal(latest) {
+		t.Errorf("projectEpoch returned %+v want height 40 at %v",
+			transition, latest)
+	}
+}
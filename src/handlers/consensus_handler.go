@@ -99,6 +99,9 @@ func GetConsensusStateToGenesis(w http.ResponseWriter, r *http.Request) {
 	// Retrieving genesis state of consensus object at specified height
 	consensusGenesis, err := co.StateToGenesis(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Genesis file of Block!"})
 
@@ -161,6 +164,9 @@ func GetEpoch(w http.ResponseWriter, r *http.Request) {
 	// Return epcoh of specific height
 	epoch, err := be.GetEpoch(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Epoch of Block!"})
 
@@ -217,6 +223,9 @@ func PingNode(w http.ResponseWriter, r *http.Request) {
 	// is pingable
 	_, err := co.GetBlock(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to ping node by retrieving highest " +
 				"block height!"})
@@ -279,6 +288,9 @@ func GetBlock(w http.ResponseWriter, r *http.Request) {
 	// Retrieve block at specific height from consensus client
 	blk, err := co.GetBlock(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Block!"})
 
@@ -341,6 +353,9 @@ func GetBlockHeader(w http.ResponseWriter, r *http.Request) {
 	// Retriving Block at specific height using Consensus client
 	blk, err := co.GetBlock(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Block!"})
 
@@ -415,6 +430,9 @@ func GetBlockLastCommit(w http.ResponseWriter, r *http.Request) {
 	// Retrieve block at specific height from consensus client
 	blk, err := co.GetBlock(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Block!"})
 
@@ -524,6 +542,9 @@ func GetTransactions(w http.ResponseWriter, r *http.Request) {
 	// height
 	transactions, err := co.GetTransactions(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Transactions!"})
 
@@ -588,6 +609,9 @@ func GetTransactionsWithResults(w http.ResponseWriter, r *http.Request) {
 	// height
 	transactions, err := co.GetTransactionsWithResults(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Transactions!"})
 
@@ -711,6 +735,9 @@ func GetStatus(w http.ResponseWriter, r *http.Request) {
 
 	st, err := co.GetStatus(context.Background())
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to retrieve Status!"})
 
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/SimplyVC/oasis_api_server/src/config"
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// Number of blocks a node can be behind before it is reported as lagging
+const defaultLagThreshold = 10
+
+// nodeConsensusStatus holds consensus status of configured node together
+// with client used to query it further.
+type nodeConsensusStatus struct {
+	name   string
+	status *consensus.Status
+	client consensus.ClientBackend
+	close  func() error
+}
+
+// loadNodeConsensusStatus connects to node and retrieves its consensus
+// status, leaving status nil if node can't be reached.
+func loadNodeConsensusStatus(name string,
+	socket string) *nodeConsensusStatus {
+
+	node := &nodeConsensusStatus{name: name}
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+	if co == nil {
+		return node
+	}
+	node.client = co
+	node.close = connection.Close
+
+	status, err := co.GetStatus(context.Background())
+	if err != nil {
+		lgr.Error.Printf("Failed to retrieve consensus Status of node "+
+			"%s : %s", name, err)
+		return node
+	}
+	node.status = status
+	return node
+}
+
+// majority returns value shared by most nodes, ties are broken by first
+// node in order.
+func majority(values []string) string {
+	counts := map[string]int{}
+	best := ""
+	for _, value := range values {
+		counts[value]++
+		if counts[value] > counts[best] {
+			best = value
+		}
+	}
+	return best
+}
+
+// GetConsistency queries all configured nodes for latest height, block hash
+// at a common height, chain context and genesis hash, reporting nodes that
+// are lagging, forked, on a different network or unreachable.
+func GetConsistency(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving lag threshold in blocks from query request
+	recvLag := r.URL.Query().Get("lag")
+	lag := checkAmount(recvLag)
+	if lag == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, lag needs to be " +
+				"a string representing an int!"})
+		return
+	}
+	if lag == 0 {
+		lag = defaultLagThreshold
+	}
+
+	// Query all configured nodes API key may query at once
+	names := []string{}
+	sockets := map[string]string{}
+	for _, node := range config.GetNodes() {
+		if !requestAllowsNode(r, node["node_name"]) {
+			continue
+		}
+		names = append(names, node["node_name"])
+		sockets[node["node_name"]] = node["isocket_path"]
+	}
+	sort.Strings(names)
+
+	nodes := make([]*nodeConsensusStatus, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			nodes[i] = loadNodeConsensusStatus(name, sockets[name])
+		}(i, name)
+	}
+	wg.Wait()
+	defer func() {
+		for _, node := range nodes {
+			if node.close != nil {
+				node.close()
+			}
+		}
+	}()
+
+	// Network of majority of reachable nodes is taken as reference
+	contexts := []string{}
+	for _, node := range nodes {
+		if node.status != nil {
+			contexts = append(contexts, node.status.ChainContext)
+		}
+	}
+	report := &responses.ConsistencyReport{
+		ChainContext: majority(contexts),
+		Consistent:   true,
+		Nodes:        []*responses.NodeConsistency{},
+	}
+
+	// Common height is highest height all nodes on network have reached,
+	// but no lower than heights pruned nodes still retain
+	var sameNetwork []*nodeConsensusStatus
+	var retained int64
+	for _, node := range nodes {
+		if node.status == nil ||
+			node.status.ChainContext != report.ChainContext {
+			continue
+		}
+		sameNetwork = append(sameNetwork, node)
+		if report.CommonHeight == 0 ||
+			node.status.LatestHeight < report.CommonHeight {
+			report.CommonHeight = node.status.LatestHeight
+		}
+		if node.status.LatestHeight > report.MaxHeight {
+			report.MaxHeight = node.status.LatestHeight
+		}
+		if node.status.LastRetainedHeight > retained {
+			retained = node.status.LastRetainedHeight
+		}
+	}
+	if report.CommonHeight < retained {
+		report.CommonHeight = retained
+	}
+
+	// Retrieve block hash at common height from every node on network,
+	// nodes behind it don't have the block yet
+	hashes := map[string][]byte{}
+	for _, node := range sameNetwork {
+		if node.status.LatestHeight < report.CommonHeight {
+			continue
+		}
+		blk, err := node.client.GetBlock(context.Background(),
+			report.CommonHeight)
+		if err != nil {
+			lgr.Error.Printf("Failed to retrieve Block of node %s : %s",
+				node.name, err)
+			continue
+		}
+		hashes[node.name] = blk.Hash
+	}
+	hashValues := []string{}
+	for _, node := range sameNetwork {
+		if hash, ok := hashes[node.name]; ok {
+			hashValues = append(hashValues, string(hash))
+		}
+	}
+	commonHash := []byte(majority(hashValues))
+
+	for _, node := range nodes {
+		result := &responses.NodeConsistency{NodeName: node.name}
+		report.Nodes = append(report.Nodes, result)
+
+		if node.status == nil {
+			result.Error = "Failed to retrieve consensus Status!"
+			report.Consistent = false
+			continue
+		}
+		result.Reachable = true
+		result.LatestHeight = node.status.LatestHeight
+		result.ChainContext = node.status.ChainContext
+		result.GenesisHash = node.status.GenesisHash
+
+		if node.status.ChainContext != report.ChainContext {
+			result.DifferentNetwork = true
+			report.Consistent = false
+			continue
+		}
+
+		result.Lag = report.MaxHeight - node.status.LatestHeight
+		if result.Lag > lag {
+			result.Lagging = true
+			report.Consistent = false
+		}
+
+		hash, ok := hashes[node.name]
+		if !ok && node.status.LatestHeight < report.CommonHeight {
+			result.Error = "Node has not reached common height, lower " +
+				"heights are pruned on other nodes!"
+			report.Consistent = false
+			continue
+		}
+		if !ok {
+			result.Error = "Failed to retrieve Block at common height!"
+			report.Consistent = false
+			continue
+		}
+		result.CommonBlockHash = hash
+		if !bytes.Equal(hash, commonHash) {
+			result.Forked = true
+			report.Consistent = false
+		}
+	}
+
+	// Responding with consistency of configured nodes
+	lgr.Info.Println("Request at /api/consistency responding with " +
+		"Consistency Report!")
+	json.NewEncoder(w).Encode(responses.ConsistencyReportResponse{
+		Report: report})
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Methods and headers allowed to browser frontends if not configured
+const (
+	defaultCORSMethods = "GET, POST, OPTIONS"
+	defaultCORSHeaders = "Content-Type, " + APIKeyHeader
+)
+
+// Origins allowed to call the API from browsers, with methods and headers
+// they may use. Cross-origin requests aren't allowed if no origin is set.
+var (
+	corsMutex   sync.RWMutex
+	corsOrigins = map[string]bool{}
+	corsMethods = defaultCORSMethods
+	corsHeaders = defaultCORSHeaders
+)
+
+// joinList returns items of comma separated list joined by comma and space,
+// or fallback if list is empty
+func joinList(list string, fallback string) string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return fallback
+	}
+	return strings.Join(items, ", ")
+}
+
+// SetCORS sets comma separated lists of origins allowed to call the API
+// from browsers, * for any origin, and of methods and headers they may use.
+func SetCORS(origins string, methods string, headers string) {
+	corsMutex.Lock()
+	defer corsMutex.Unlock()
+	corsOrigins = splitList(origins)
+	corsMethods = joinList(methods, defaultCORSMethods)
+	corsHeaders = joinList(headers, defaultCORSHeaders)
+}
+
+// CORS allows configured origins to call the API from browsers, answering
+// preflight requests itself. It wraps router, as preflight requests don't
+// match methods of routes.
+func CORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if len(origin) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		corsMutex.RLock()
+		allowed := corsOrigins[scopeAll] || corsOrigins[origin]
+		methods, headers := corsMethods, corsHeaders
+		corsMutex.RUnlock()
+
+		preflight := r.Method == http.MethodOptions &&
+			len(r.Header.Get("Access-Control-Request-Method")) > 0
+
+		w.Header().Add("Vary", "Origin")
+		if !allowed {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		if preflight {
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Access-Control-Expose-Headers",
+			BackendHeader+", ETag, Retry-After")
+		next.ServeHTTP(w, r)
+	})
+}
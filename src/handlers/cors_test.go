@@ -0,0 +1,57 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+func Test_CORS_Preflight(t *testing.T) {
+	hdl.SetCORS("https://dashboard.example", "", "")
+	defer hdl.SetCORS("", "", "")
+
+	req, _ := http.NewRequest("OPTIONS", "/api/ping", nil)
+	req.Header.Set("Origin", "https://dashboard.example")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+
+	rr := httptest.NewRecorder()
+	hdl.CORS(http.HandlerFunc(hdl.Pong)).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusNoContent)
+	}
+	expected := map[string]string{
+		"Access-Control-Allow-Origin":  "https://dashboard.example",
+		"Access-Control-Allow-Methods": "GET, POST, OPTIONS",
+		"Access-Control-Allow-Headers": "Content-Type, X-API-Key",
+	}
+	for header, value := range expected {
+		if got := rr.Header().Get(header); got != value {
+			t.Errorf("handler returned unexpected %s: got %v want %v",
+				header, got, value)
+		}
+	}
+}
+
+func Test_CORS_OriginNotAllowed(t *testing.T) {
+	hdl.SetCORS("https://dashboard.example", "GET", "")
+	defer hdl.SetCORS("", "", "")
+
+	req, _ := http.NewRequest("GET", "/api/ping", nil)
+	req.Header.Set("Origin", "https://other.example")
+
+	rr := httptest.NewRecorder()
+	hdl.CORS(http.HandlerFunc(hdl.Pong)).ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if got := rr.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("handler returned unexpected origin: got %v want none",
+			got)
+	}
+}
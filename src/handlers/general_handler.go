@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/SimplyVC/oasis_api_server/src/cache"
 	"github.com/SimplyVC/oasis_api_server/src/config"
 	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
 	"github.com/SimplyVC/oasis_api_server/src/responses"
 )
 
@@ -35,6 +37,9 @@ func GetConnections(w http.ResponseWriter, r *http.Request) {
 
 	lgr.Info.Println("Iterating through all socket connections.")
 	for _, socket := range allSockets {
+		if !requestAllowsNode(r, socket["node_name"]) {
+			continue
+		}
 		lgr.Info.Printf("Node: %s has socket %s \n",
 			socket["node_name"], socket["isocket_path"])
 		connectionsResponse = append(connectionsResponse,
@@ -45,3 +50,35 @@ func GetConnections(w http.ResponseWriter, r *http.Request) {
 		Results: connectionsResponse})
 	mutex.Unlock()
 }
+
+// Cache of responses whose statistics are reported
+var responseCache *cache.Cache
+
+// SetResponseCache sets cache of responses whose statistics are reported
+func SetResponseCache(c *cache.Cache) {
+	responseCache = c
+	metrics.SetCacheStats(c.Stats)
+}
+
+// GetCacheStats responds with statistics of response cache
+func GetCacheStats(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+	lgr.Info.Println("Received request for /api/cache/stats")
+
+	if responseCache == nil {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Response cache is disabled!"})
+		return
+	}
+
+	stats := responseCache.Stats()
+	json.NewEncoder(w).Encode(responses.CacheStatsResponse{
+		Stats: &stats})
+}
+
+// GetMetrics responds with Prometheus metrics of the API server itself
+func GetMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics.Handler().ServeHTTP(w, r)
+}
@@ -6,11 +6,22 @@ import (
 	"google.golang.org/grpc"
 	"net/http"
 	"strconv"
+	"sync"
 
+	"github.com/SimplyVC/oasis_api_server/src/config"
 	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
 	"github.com/SimplyVC/oasis_api_server/src/responses"
 	"github.com/SimplyVC/oasis_api_server/src/rpc"
+	common_signature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
 // loadGovernanceClient loads governance client and returns it
@@ -74,6 +85,9 @@ func GetActiveProposals(w http.ResponseWriter, r *http.Request) {
 	// Retrieve ActiveProposals at specific block height
 	proposals, err := ro.ActiveProposals(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get ActiveProposals!"})
 		lgr.Error.Println("Request at /api/governance/activeproposals failed "+
@@ -136,6 +150,9 @@ func GetProposals(w http.ResponseWriter, r *http.Request) {
 	// Retrieve Proposals at specific block height
 	proposals, err := ro.Proposals(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Proposals!"})
 		lgr.Error.Println("Request at /api/governance/proposals failed "+
@@ -211,6 +228,9 @@ func GetProposal(w http.ResponseWriter, r *http.Request) {
 	// Retrieve Proposals at specific block height
 	proposal, err := ro.Proposal(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Proposal!"})
 		lgr.Error.Println("Request at /api/governance/proposal failed "+
@@ -286,6 +306,9 @@ func GetVotes(w http.ResponseWriter, r *http.Request) {
 	// Retrieve Votes at a specific proposal
 	votes, err := ro.Votes(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Votes!"})
 		lgr.Error.Println("Request at /api/governance/votes failed "+
@@ -299,3 +322,963 @@ func GetVotes(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(responses.VotesResponse{
 		Votes: votes})
 }
+
+// percentage returns part as a whole percentage of total, rounded down the
+// same way governance does when closing proposals.
+func percentage(part, total *quantity.Quantity) uint64 {
+	if total.IsZero() {
+		return 0
+	}
+	percent := part.Clone()
+	_ = percent.Mul(quantity.NewFromUint64(100))
+	_ = percent.Quo(total)
+	return percent.ToBigInt().Uint64()
+}
+
+// tallyProposal weighs votes by escrow of voting validator entities and
+// evaluates results against quorum and threshold of governance parameters.
+func tallyProposal(proposal *governance.Proposal,
+	votes []*governance.VoteEntry,
+	voters []*responses.VoterStake,
+	params *governance.ConsensusParameters) *responses.ProposalTally {
+
+	tally := &responses.ProposalTally{
+		ProposalID: proposal.ID,
+		State:      proposal.State,
+		Quorum:     params.Quorum,
+		Threshold:  params.Threshold,
+		NonVoters:  []*responses.VoterStake{},
+	}
+
+	escrows := map[staking.Address]*quantity.Quantity{}
+	for _, voter := range voters {
+		escrows[voter.Address] = &voter.Escrow
+		_ = tally.TotalVotingStake.Add(&voter.Escrow)
+	}
+
+	// Votes of entities outside of validator set are not counted
+	voted := map[staking.Address]bool{}
+	for _, vote := range votes {
+		escrow, ok := escrows[vote.Voter]
+		if !ok {
+			tally.InvalidVotes++
+			continue
+		}
+		voted[vote.Voter] = true
+
+		switch vote.Vote {
+		case governance.VoteYes:
+			_ = tally.Yes.Add(escrow)
+		case governance.VoteNo:
+			_ = tally.No.Add(escrow)
+		case governance.VoteAbstain:
+			_ = tally.Abstain.Add(escrow)
+		}
+		_ = tally.VotedStake.Add(escrow)
+	}
+
+	for _, voter := range voters {
+		if !voted[voter.Address] {
+			tally.NonVoters = append(tally.NonVoters, voter)
+		}
+	}
+
+	tally.Participation = percentage(&tally.VotedStake,
+		&tally.TotalVotingStake)
+	tally.YesPercentage = percentage(&tally.Yes, &tally.VotedStake)
+	tally.QuorumReached = !tally.TotalVotingStake.IsZero() &&
+		tally.Participation >= uint64(params.Quorum)
+	tally.ThresholdReached = !tally.Yes.IsZero() &&
+		tally.YesPercentage >= uint64(params.Threshold)
+	return tally
+}
+
+// GetProposalTally tallies votes of a proposal weighted by escrow of voting
+// validator entities at the proposal's closing height, or at latest height
+// for proposals that are still active.
+func GetProposalTally(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving proposal id from query request
+	proposalID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, id needs to be a string " +
+				"representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with governance client
+	connection, ro := loadGovernanceClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with scheduler client
+	schedulerConnection, sc := loadSchedulerClient(socket)
+
+	// Close connection once code underneath executes
+	defer schedulerConnection.Close()
+
+	// If null object was retrieved send response
+	if sc == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with registry client
+	registryConnection, rg := loadRegistryClient(socket)
+
+	// Close connection once code underneath executes
+	defer registryConnection.Close()
+
+	// If null object was retrieved send response
+	if rg == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with staking client
+	stakingConnection, so := loadStakingClient(socket)
+
+	// Close connection once code underneath executes
+	defer stakingConnection.Close()
+
+	// If null object was retrieved send response
+	if so == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve proposal at latest height to learn whether it has closed
+	proposal, err := ro.Proposal(context.Background(),
+		&governance.ProposalQuery{Height: consensus.HeightLatest,
+			ProposalID: proposalID})
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Proposal!"})
+		lgr.Error.Println("Request at /api/governance/tally failed "+
+			"to retrieve Proposal : ", err)
+		return
+	}
+
+	// Closed proposals are tallied on state right before the block that
+	// closed them, at the start of their closing epoch
+	height := consensus.HeightLatest
+	if proposal.State != governance.StateActive {
+		closingHeight, err := be.GetEpochBlock(context.Background(),
+			proposal.ClosesAt)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Epoch Block!"})
+			lgr.Error.Println("Request at /api/governance/tally failed "+
+				"to retrieve Epoch Block : ", err)
+			return
+		}
+		height = closingHeight - 1
+	}
+
+	// Retrieve governance parameters containing quorum and threshold
+	params, err := ro.ConsensusParameters(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Governance Consensus Parameters!"})
+		lgr.Error.Println("Request at /api/governance/tally failed "+
+			"to retrieve Governance Consensus Parameters : ", err)
+		return
+	}
+
+	// Retrieve votes cast on proposal
+	votes, err := ro.Votes(context.Background(),
+		&governance.ProposalQuery{Height: height, ProposalID: proposalID})
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Votes!"})
+		lgr.Error.Println("Request at /api/governance/tally failed "+
+			"to retrieve Votes : ", err)
+		return
+	}
+
+	// Retrieve validator set whose entities are allowed to vote
+	validators, err := sc.GetValidators(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Validators!"})
+		lgr.Error.Println("Request at /api/governance/tally failed "+
+			"to retrieve Validators : ", err)
+		return
+	}
+
+	// Every validator entity counts once regardless of number of nodes
+	voters := []*responses.VoterStake{}
+	seen := map[common_signature.PublicKey]bool{}
+	for _, validator := range validators {
+		node, err := rg.GetNode(context.Background(),
+			&registry.IDQuery{Height: height, ID: validator.ID})
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Node!"})
+			lgr.Error.Println("Request at /api/governance/tally failed "+
+				"to retrieve Node : ", err)
+			return
+		}
+		if seen[node.EntityID] {
+			continue
+		}
+		seen[node.EntityID] = true
+
+		address := staking.NewAddress(node.EntityID)
+		account, err := so.Account(context.Background(),
+			&staking.OwnerQuery{Height: height, Owner: address})
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Account!"})
+			lgr.Error.Println("Request at /api/governance/tally failed "+
+				"to retrieve Account : ", err)
+			return
+		}
+		voters = append(voters, &responses.VoterStake{
+			EntityID: node.EntityID,
+			Address:  address,
+			Escrow:   account.Escrow.Active.Balance,
+		})
+	}
+
+	tally := tallyProposal(proposal, votes, voters, params)
+	tally.Height = height
+
+	// Responding with tallied proposal
+	lgr.Info.Println("Request at /api/governance/tally responding with" +
+		" Proposal Tally!")
+	json.NewEncoder(w).Encode(responses.ProposalTallyResponse{
+		Tally: tally})
+}
+
+// loadNodeStatus retrieves status of configured node, returning nil status
+// if node can't be reached so that other nodes can still be checked.
+func loadNodeStatus(nodeName string, socket string) *control.Status {
+
+	// Attempt to load connection with node controller client
+	connection, nc := loadNodeControllerClient(socket)
+	if nc == nil {
+		return nil
+	}
+	defer connection.Close()
+
+	status, err := nc.GetStatus(context.Background())
+	if err != nil {
+		lgr.Error.Printf("Failed to retrieve Status of node %s : %s",
+			nodeName, err)
+		return nil
+	}
+	return status
+}
+
+// upgradeReadiness reports whether node has recorded given upgrade as
+// pending, which it does once it has seen the upgrade and is able to stop
+// at the upgrade epoch.
+func upgradeReadiness(nodeName string, status *control.Status,
+	descriptor *upgrade.Descriptor) *responses.NodeUpgradeReadiness {
+
+	readiness := &responses.NodeUpgradeReadiness{NodeName: nodeName}
+	if status == nil {
+		readiness.Error = "Failed to retrieve Status of node!"
+		return readiness
+	}
+	readiness.SoftwareVersion = status.SoftwareVersion
+
+	for _, pending := range status.PendingUpgrades {
+		if pending.Descriptor == nil || !pending.Descriptor.Equals(descriptor) {
+			continue
+		}
+		readiness.Ready = true
+		readiness.UpgradeHeight = pending.UpgradeHeight
+		readiness.LastCompletedStage = pending.LastCompletedStage
+	}
+	return readiness
+}
+
+// GetPendingUpgrades returns upgrades accepted by governance that have not
+// been executed yet, with estimated time of upgrade epoch and whether each
+// configured node is ready for them.
+func GetPendingUpgrades(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Attempt to load connection with governance client
+	connection, ro := loadGovernanceClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with consensus client
+	consensusConnection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer consensusConnection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve upgrades that are pending at latest height
+	descriptors, err := ro.PendingUpgrades(context.Background(),
+		consensus.HeightLatest)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Pending Upgrades!"})
+		lgr.Error.Println("Request at /api/governance/pendingupgrades "+
+			"failed to retrieve Pending Upgrades : ", err)
+		return
+	}
+
+	// Retrieve epoch timing used to estimate upgrade time
+	timing, err := estimateEpochTiming(co, be)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Timing!"})
+		lgr.Error.Println("Request at /api/governance/pendingupgrades "+
+			"failed to retrieve Epoch Timing : ", err)
+		return
+	}
+
+	// Retrieve status of every configured node API key may query once
+	nodeNames := []string{}
+	statuses := map[string]*control.Status{}
+	for _, node := range config.GetNodes() {
+		if !requestAllowsNode(r, node["node_name"]) {
+			continue
+		}
+		nodeNames = append(nodeNames, node["node_name"])
+		statuses[node["node_name"]] = loadNodeStatus(node["node_name"],
+			node["isocket_path"])
+	}
+
+	upgrades := []*responses.PendingUpgrade{}
+	for _, descriptor := range descriptors {
+		eta, err := epochTransition(co, be, timing, descriptor.Epoch)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to retrieve Epoch Transition!"})
+			lgr.Error.Println("Request at /api/governance/"+
+				"pendingupgrades failed to retrieve Epoch Transition : ",
+				err)
+			return
+		}
+
+		pending := &responses.PendingUpgrade{
+			Descriptor: descriptor,
+			ETA:        eta,
+			Nodes:      []*responses.NodeUpgradeReadiness{},
+		}
+		for _, name := range nodeNames {
+			pending.Nodes = append(pending.Nodes,
+				upgradeReadiness(name, statuses[name], descriptor))
+		}
+		upgrades = append(upgrades, pending)
+	}
+
+	// Responding with pending upgrades and readiness of nodes
+	lgr.Info.Println("Request at /api/governance/pendingupgrades " +
+		"responding with Pending Upgrades!")
+	json.NewEncoder(w).Encode(responses.PendingUpgradesResponse{
+		Upgrades: upgrades})
+}
+
+// GetGovernanceParameters returns governance consensus parameters at
+// specified block height.
+func GetGovernanceParameters(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, height needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with governance client
+	connection, ro := loadGovernanceClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve governance parameters at specific block height
+	params, err := ro.ConsensusParameters(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Governance Consensus Parameters!"})
+		lgr.Error.Println("Request at /api/governance/parameters failed "+
+			"to retrieve Governance Consensus Parameters : ", err)
+		return
+	}
+
+	// Responding with retrieved governance parameters
+	lgr.Info.Println("Request at /api/governance/parameters responding " +
+		"with Governance Consensus Parameters!")
+	json.NewEncoder(w).Encode(responses.GovernanceParametersResponse{
+		Parameters: params})
+}
+
+// GetProposalTimeline returns submission and closing epochs of a proposal
+// with the dates at which they start, estimated if not reached yet.
+func GetProposalTimeline(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving proposal id from query request
+	proposalID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, id needs to be a string " +
+				"representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with governance client
+	connection, ro := loadGovernanceClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with consensus client
+	consensusConnection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer consensusConnection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve proposal at latest height
+	proposal, err := ro.Proposal(context.Background(),
+		&governance.ProposalQuery{Height: consensus.HeightLatest,
+			ProposalID: proposalID})
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Proposal!"})
+		lgr.Error.Println("Request at /api/governance/timeline failed "+
+			"to retrieve Proposal : ", err)
+		return
+	}
+
+	// Retrieve epoch timing used to estimate closing time
+	timing, err := estimateEpochTiming(co, be)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Timing!"})
+		lgr.Error.Println("Request at /api/governance/timeline failed "+
+			"to retrieve Epoch Timing : ", err)
+		return
+	}
+
+	submitted, err := epochTransition(co, be, timing, proposal.CreatedAt)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Transition!"})
+		lgr.Error.Println("Request at /api/governance/timeline failed "+
+			"to retrieve submission Epoch Transition : ", err)
+		return
+	}
+
+	closes, err := epochTransition(co, be, timing, proposal.ClosesAt)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch Transition!"})
+		lgr.Error.Println("Request at /api/governance/timeline failed "+
+			"to retrieve closing Epoch Transition : ", err)
+		return
+	}
+
+	// Responding with proposal timeline
+	lgr.Info.Println("Request at /api/governance/timeline responding " +
+		"with Proposal Timeline!")
+	json.NewEncoder(w).Encode(responses.ProposalTimelineResponse{
+		Timeline: &responses.ProposalTimeline{
+			ID:           proposal.ID,
+			Submitter:    proposal.Submitter,
+			Deposit:      proposal.Deposit,
+			State:        proposal.State,
+			Content:      proposal.Content,
+			CurrentEpoch: timing.Epoch,
+			Submitted:    submitted,
+			Closes:       closes,
+		}})
+}
+
+// Vote casting is only served if enabled explicitly, and only while API
+// keys are required so that unauthenticated clients can't sign votes
+var (
+	voteCastingMutex sync.RWMutex
+	voteCasting      bool
+)
+
+// SetVoteCasting sets whether votes may be cast through the API, routes
+// casting votes are registered only if it's enabled and API keys are set.
+func SetVoteCasting(enabled bool) {
+	voteCastingMutex.Lock()
+	defer voteCastingMutex.Unlock()
+	voteCasting = enabled
+}
+
+// voteCastingEnabled returns whether votes may be cast through the API
+func voteCastingEnabled() bool {
+	voteCastingMutex.RLock()
+	enabled := voteCasting
+	voteCastingMutex.RUnlock()
+
+	apiKeysMutex.RLock()
+	defer apiKeysMutex.RUnlock()
+	return enabled && len(apiKeys) > 0
+}
+
+// Chain context used for signing is global, nodes of different networks
+// must not sign at the same time
+var signMutex sync.Mutex
+
+// signTransaction signs transaction for chain with given chain context.
+func signTransaction(signer common_signature.Signer, chainContext string,
+	tx *transaction.Transaction) (*transaction.SignedTransaction, error) {
+
+	signMutex.Lock()
+	defer signMutex.Unlock()
+
+	common_signature.UnsafeResetChainContext()
+	common_signature.SetChainContext(chainContext)
+	return transaction.Sign(signer, tx)
+}
+
+// CastVote builds a governance vote transaction on behalf of a configured
+// entity signer, estimating its nonce and gas through the node, and signs
+// and submits it unless a dry run is requested.
+func CastVote(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Browsers can't send JSON requests to other origins without CORS
+	// allowing it, unlike forms
+	if !jsonRequest(r) {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected Content-Type, requests need to be sent " +
+				"as application/json!"})
+		return
+	}
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving name of signer from query request
+	signerName := r.URL.Query().Get("signer")
+	confirmation, entityDir, recvGasPrice := checkSignerName(signerName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Signer name requested doesn't exist"})
+		return
+	}
+
+	// Gas price is optional and defaults to zero
+	gasPrice := uint64(0)
+	if len(recvGasPrice) > 0 {
+		var err error
+		gasPrice, err = strconv.ParseUint(recvGasPrice, 10, 64)
+		if err != nil {
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Signer gas_price needs to be an int!"})
+			return
+		}
+	}
+
+	// Retrieving proposal id from query request
+	proposalID, err := strconv.ParseUint(r.URL.Query().Get("id"), 10, 64)
+	if err != nil {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, id needs to be a string " +
+				"representing an int!"})
+		return
+	}
+
+	// Retrieving vote choice from query request
+	var vote governance.Vote
+	if err = vote.UnmarshalText([]byte(r.URL.Query().Get("vote"))); err != nil {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, vote needs to be one of " +
+				"yes, no or abstain!"})
+		return
+	}
+
+	// Dry run returns unsigned transaction instead of submitting it
+	dryRun := r.URL.Query().Get("dryrun") == "true"
+
+	// Load entity signer from its directory
+	factory, err := fileSigner.NewFactory(entityDir,
+		common_signature.SignerEntity)
+	if err != nil {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to load Signer!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to create Signer factory : ", err)
+		return
+	}
+	signer, err := factory.Load(common_signature.SignerEntity)
+	if err != nil {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to load Signer!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to load Signer : ", err)
+		return
+	}
+	defer signer.Reset()
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve nonce of entity account
+	nonce, err := co.GetSignerNonce(context.Background(),
+		&consensus.GetSignerNonceRequest{
+			AccountAddress: staking.NewAddress(signer.Public()),
+			Height:         consensus.HeightLatest,
+		})
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Signer Nonce!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to retrieve Signer Nonce : ", err)
+		return
+	}
+
+	// Estimate gas needed by vote transaction
+	tx := governance.NewCastVoteTx(nonce, nil, &governance.ProposalVote{
+		ID:   proposalID,
+		Vote: vote,
+	})
+	gas, err := co.EstimateGas(context.Background(),
+		&consensus.EstimateGasRequest{
+			Signer:      signer.Public(),
+			Transaction: tx,
+		})
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to estimate Gas!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to estimate Gas : ", err)
+		return
+	}
+
+	tx.Fee = &transaction.Fee{Gas: gas}
+	err = tx.Fee.Amount.FromUint64(uint64(gas))
+	if err == nil {
+		err = tx.Fee.Amount.Mul(quantity.NewFromUint64(gasPrice))
+	}
+	if err != nil {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to compute Fee!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to compute Fee : ", err)
+		return
+	}
+
+	result := &responses.CastVoteResult{
+		Signer:      signer.Public(),
+		Transaction: tx,
+	}
+	if dryRun {
+		lgr.Info.Println("Request at /api/governance/castvote responding " +
+			"with unsigned Vote Transaction!")
+		json.NewEncoder(w).Encode(responses.CastVoteResponse{
+			CastVote: result})
+		return
+	}
+
+	// Retrieve chain context to sign transaction for node's network
+	chainContext, err := co.GetChainContext(context.Background())
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Chain Context!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to retrieve Chain Context : ", err)
+		return
+	}
+
+	sigTx, err := signTransaction(signer, chainContext, tx)
+	if err != nil {
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to sign Vote Transaction!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to sign Vote Transaction : ", err)
+		return
+	}
+
+	if err = co.SubmitTx(context.Background(), sigTx); err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to submit Vote Transaction!"})
+		lgr.Error.Println("Request at /api/governance/castvote failed "+
+			"to submit Vote Transaction : ", err)
+		return
+	}
+
+	// Responding with hash of submitted transaction
+	hash := sigTx.Hash()
+	result.Hash = &hash
+	result.Submitted = true
+	lgr.Info.Println("Request at /api/governance/castvote responding " +
+		"with submitted Vote Transaction!")
+	json.NewEncoder(w).Encode(responses.CastVoteResponse{
+		CastVote: result})
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// testVoterAddress returns address of entity identified by id
+func testVoterAddress(id byte) staking.Address {
+	var entity signature.PublicKey
+	entity[0] = id
+	return staking.NewAddress(entity)
+}
+
+func Test_TallyProposal(t *testing.T) {
+	type vote struct {
+		voter byte
+		vote  governance.Vote
+	}
+
+	tests := []struct {
+		name          string
+		escrows       []uint64
+		votes         []vote
+		yes, no       uint64
+		abstain       uint64
+		participation uint64
+		yesPercentage uint64
+		quorum        bool
+		threshold     bool
+		invalid       uint64
+		nonVoters     []byte
+	}{
+		{
+			name:    "AcceptedAtThreshold",
+			escrows: []uint64{600, 300, 100},
+			votes: []vote{{1, governance.VoteYes},
+				{2, governance.VoteYes}, {3, governance.VoteAbstain}},
+			yes: 900, abstain: 100, participation: 100,
+			yesPercentage: 90, quorum: true, threshold: true,
+		},
+		{
+			name:          "QuorumMissed",
+			escrows:       []uint64{600, 300, 100},
+			votes:         []vote{{1, governance.VoteYes}},
+			yes:           600,
+			participation: 60, yesPercentage: 100, threshold: true,
+			nonVoters: []byte{2, 3},
+		},
+		{
+			name:    "ThresholdMissed",
+			escrows: []uint64{600, 300, 100},
+			votes: []vote{{1, governance.VoteYes},
+				{2, governance.VoteNo}},
+			yes: 600, no: 300, participation: 90, yesPercentage: 66,
+			quorum: true, nonVoters: []byte{3},
+		},
+		{
+			name:          "ParticipationRoundedDown",
+			escrows:       []uint64{749, 250, 1},
+			votes:         []vote{{1, governance.VoteYes}},
+			yes:           749,
+			participation: 74, yesPercentage: 100, threshold: true,
+			nonVoters: []byte{2, 3},
+		},
+		{
+			name:    "VoteOutsideValidatorSet",
+			escrows: []uint64{600, 300, 100},
+			votes: []vote{{1, governance.VoteYes},
+				{2, governance.VoteYes}, {9, governance.VoteNo}},
+			yes: 900, participation: 90, yesPercentage: 100,
+			quorum: true, threshold: true, invalid: 1,
+			nonVoters: []byte{3},
+		},
+		{
+			name:      "NoVotes",
+			escrows:   []uint64{600, 300, 100},
+			nonVoters: []byte{1, 2, 3},
+		},
+	}
+	params := &governance.ConsensusParameters{Quorum: 75, Threshold: 90}
+	for _, test := range tests {
+		voters := []*responses.VoterStake{}
+		for i, escrow := range test.escrows {
+			voter := &responses.VoterStake{
+				Address: testVoterAddress(byte(i + 1)),
+				Escrow:  *quantity.NewFromUint64(escrow),
+			}
+			voter.EntityID[0] = byte(i + 1)
+			voters = append(voters, voter)
+		}
+		votes := []*governance.VoteEntry{}
+		for _, v := range test.votes {
+			votes = append(votes, &governance.VoteEntry{
+				Voter: testVoterAddress(v.voter), Vote: v.vote})
+		}
+
+		tally := tallyProposal(&governance.Proposal{ID: 1}, votes, voters,
+			params)
+
+		for _, sum := range []struct {
+			name string
+			got  quantity.Quantity
+			want uint64
+		}{
+			{"yes", tally.Yes, test.yes},
+			{"no", tally.No, test.no},
+			{"abstain", tally.Abstain, test.abstain},
+		} {
+			if sum.got.Cmp(quantity.NewFromUint64(sum.want)) != 0 {
+				t.Errorf("%s: %s stake %s want %d", test.name, sum.name,
+					sum.got.String(), sum.want)
+			}
+		}
+		if tally.Participation != test.participation ||
+			tally.YesPercentage != test.yesPercentage {
+			t.Errorf("%s: participation %d%% and yes %d%% want %d%% "+
+				"and %d%%", test.name, tally.Participation,
+				tally.YesPercentage, test.participation,
+				test.yesPercentage)
+		}
+		if tally.QuorumReached != test.quorum ||
+			tally.ThresholdReached != test.threshold {
+			t.Errorf("%s: quorum reached %v and threshold reached %v "+
+				"want %v and %v", test.name, tally.QuorumReached,
+				tally.ThresholdReached, test.quorum, test.threshold)
+		}
+		if tally.InvalidVotes != test.invalid {
+			t.Errorf("%s: %d invalid votes want %d", test.name,
+				tally.InvalidVotes, test.invalid)
+		}
+		nonVoters := []byte{}
+		for _, voter := range tally.NonVoters {
+			nonVoters = append(nonVoters, voter.EntityID[0])
+		}
+		if string(nonVoters) != string(test.nonVoters) {
+			t.Errorf("%s: non voters %v want %v", test.name, nonVoters,
+				test.nonVoters)
+		}
+	}
+}
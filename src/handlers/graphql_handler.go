@@ -0,0 +1,846 @@
+package handlers
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"net/http"
+	"sort"
+
+	"github.com/SimplyVC/oasis_api_server/src/graphql"
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// Limits of GraphQL queries, fields of lists count as ten fields towards
+// complexity. Bodies of POST requests are read up to graphQLMaxBodyBytes.
+const (
+	graphQLMaxDepth      = 10
+	graphQLMaxComplexity = 1000
+	graphQLMaxBodyBytes  = 1 << 20
+)
+
+// graphQLRequest is body of a GraphQL request
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// graphQLQuery holds state shared by resolvers of a single query, the
+// connection to node is only established once a field needs it. Limited is
+// set once a call to node is rejected by call limits.
+type graphQLQuery struct {
+	ctx     context.Context
+	socket  string
+	height  int64
+	conn    *grpc.ClientConn
+	limited bool
+}
+
+// connection returns connection to node, establishing it on first use
+func (q *graphQLQuery) connection() (*grpc.ClientConn, error) {
+	if q.conn != nil {
+		return q.conn, nil
+	}
+	conn, err := rpc.Connect(q.socket)
+	if err != nil {
+		lgr.Error.Println("Request at /graphql failed to establish "+
+			"connection : ", err)
+		return nil, fmt.Errorf("Failed to establish connection using "+
+			"socket: %s", q.socket)
+	}
+	q.conn = conn
+	return conn, nil
+}
+
+// close closes connection to node if it was established
+func (q *graphQLQuery) close() {
+	if q.conn != nil {
+		q.conn.Close()
+	}
+}
+
+// consensus returns consensus client, pinning latest height on first use so
+// that all fields of query are read at same height
+func (q *graphQLQuery) consensus() (consensus.ClientBackend, error) {
+	conn, err := q.connection()
+	if err != nil {
+		return nil, err
+	}
+	co := consensus.NewConsensusClient(conn)
+	if q.height == consensus.HeightLatest {
+		status, err := co.GetStatus(q.ctx)
+		if err != nil {
+			return nil, q.fail("latest height", err)
+		}
+		q.height = status.LatestHeight
+	}
+	return co, nil
+}
+
+// at returns height query is read at together with connection to node
+func (q *graphQLQuery) at() (int64, *grpc.ClientConn, error) {
+	if _, err := q.consensus(); err != nil {
+		return 0, nil, err
+	}
+	return q.height, q.conn, nil
+}
+
+// fail logs error of backend and returns error shown to client, calls
+// rejected by call limits are reported apart so clients know to retry
+func (q *graphQLQuery) fail(message string, err error) error {
+	lgr.Error.Println("Request at /graphql failed to retrieve "+message+
+		" : ", err)
+	if errors.Is(err, rpc.ErrCallLimit) {
+		q.limited = true
+		return fmt.Errorf("Too many requests to node, retry later!")
+	}
+	return fmt.Errorf("Failed to retrieve %s!", message)
+}
+
+// graphQLAccount is an account resolved only once its state is selected
+type graphQLAccount struct {
+	address staking.Address
+	account *staking.Account
+}
+
+// graphQLDelegation is a delegation of an account to an escrow account
+type graphQLDelegation struct {
+	escrow *graphQLAccount
+	shares quantity.Quantity
+}
+
+// graphQLTransaction is a transaction of a block with its result
+type graphQLTransaction struct {
+	signed *transaction.SignedTransaction
+	tx     *transaction.Transaction
+	result *results.Result
+}
+
+// graphQLEpoch is an epoch of beacon
+type graphQLEpoch struct {
+	epoch beacon.EpochTime
+}
+
+// publicKeyArgument parses public key argument of field
+func publicKeyArgument(args graphql.Arguments,
+	name string) (signature.PublicKey, error) {
+
+	var pubKey signature.PublicKey
+	text, ok := args.String(name)
+	if !ok {
+		return pubKey, fmt.Errorf("Argument %q of type String is "+
+			"required", name)
+	}
+	if err := pubKey.UnmarshalText([]byte(text)); err != nil {
+		return pubKey, fmt.Errorf("Failed to UnmarshalText into " +
+			"Public Key.")
+	}
+	return pubKey, nil
+}
+
+// newGraphQLSchema builds schema resolving fields against node of query
+func newGraphQLSchema(q *graphQLQuery) *graphql.Schema {
+	blockType := &graphql.Object{Name: "Block"}
+	transactionType := &graphql.Object{Name: "Transaction"}
+	accountType := &graphql.Object{Name: "Account"}
+	delegationType := &graphql.Object{Name: "Delegation"}
+	entityType := &graphql.Object{Name: "Entity"}
+	nodeType := &graphql.Object{Name: "Node"}
+	nodeStatusType := &graphql.Object{Name: "NodeStatus"}
+	runtimeType := &graphql.Object{Name: "Runtime"}
+	proposalType := &graphql.Object{Name: "Proposal"}
+	voteType := &graphql.Object{Name: "Vote"}
+	epochType := &graphql.Object{Name: "Epoch"}
+
+	// Resolvers loading objects shared by several fields, each object is
+	// only retrieved once per query as all are read at same height
+	accounts := map[staking.Address]*staking.Account{}
+	entities := map[signature.PublicKey]*entity.Entity{}
+	nodes := map[signature.PublicKey]*node.Node{}
+	runtimes := map[common.Namespace]*registry.Runtime{}
+	loadAccount := func(a *graphQLAccount) (*staking.Account, error) {
+		if a.account != nil {
+			return a.account, nil
+		}
+		if account, ok := accounts[a.address]; ok {
+			a.account = account
+			return account, nil
+		}
+		height, conn, err := q.at()
+		if err != nil {
+			return nil, err
+		}
+		account, err := staking.NewStakingClient(conn).Account(q.ctx,
+			&staking.OwnerQuery{Height: height, Owner: a.address})
+		if err != nil {
+			return nil, q.fail("Account", err)
+		}
+		accounts[a.address] = account
+		a.account = account
+		return account, nil
+	}
+	loadEntity := func(id signature.PublicKey) (interface{}, error) {
+		if ent, ok := entities[id]; ok {
+			return ent, nil
+		}
+		height, conn, err := q.at()
+		if err != nil {
+			return nil, err
+		}
+		ent, err := registry.NewRegistryClient(conn).GetEntity(q.ctx,
+			&registry.IDQuery{Height: height, ID: id})
+		if err != nil {
+			return nil, q.fail("Entity", err)
+		}
+		entities[id] = ent
+		return ent, nil
+	}
+	loadNode := func(id signature.PublicKey) (interface{}, error) {
+		if nod, ok := nodes[id]; ok {
+			return nod, nil
+		}
+		height, conn, err := q.at()
+		if err != nil {
+			return nil, err
+		}
+		nod, err := registry.NewRegistryClient(conn).GetNode(q.ctx,
+			&registry.IDQuery{Height: height, ID: id})
+		if err != nil {
+			return nil, q.fail("Node", err)
+		}
+		nodes[id] = nod
+		return nod, nil
+	}
+	loadRuntime := func(id common.Namespace) (interface{}, error) {
+		if runtime, ok := runtimes[id]; ok {
+			return runtime, nil
+		}
+		height, conn, err := q.at()
+		if err != nil {
+			return nil, err
+		}
+		runtime, err := registry.NewRegistryClient(conn).GetRuntime(q.ctx,
+			&registry.NamespaceQuery{Height: height, ID: id})
+		if err != nil {
+			return nil, q.fail("Runtime", err)
+		}
+		runtimes[id] = runtime
+		return runtime, nil
+	}
+	accountField := func(
+		address func(interface{}) staking.Address) *graphql.FieldDef {
+		return &graphql.FieldDef{Type: accountType, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			return &graphQLAccount{address: address(source)}, nil
+		}}
+	}
+	accountValue := func(
+		value func(*staking.Account) interface{}) *graphql.FieldDef {
+		return &graphql.FieldDef{Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			account, err := loadAccount(source.(*graphQLAccount))
+			if err != nil {
+				return nil, err
+			}
+			return value(account), nil
+		}}
+	}
+	value := func(value func(interface{}) interface{}) *graphql.FieldDef {
+		return &graphql.FieldDef{Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			return value(source), nil
+		}}
+	}
+
+	blockType.Fields = map[string]*graphql.FieldDef{
+		"height": value(func(s interface{}) interface{} {
+			return s.(*consensus.Block).Height
+		}),
+		"hash": value(func(s interface{}) interface{} {
+			return hex.EncodeToString(s.(*consensus.Block).Hash)
+		}),
+		"time": value(func(s interface{}) interface{} {
+			return s.(*consensus.Block).Time
+		}),
+		"transactions": {Type: transactionType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			co, err := q.consensus()
+			if err != nil {
+				return nil, err
+			}
+			txs, err := co.GetTransactionsWithResults(q.ctx,
+				source.(*consensus.Block).Height)
+			if err != nil {
+				return nil, q.fail("Transactions", err)
+			}
+			list := []*graphQLTransaction{}
+			for i, raw := range txs.Transactions {
+				var signed transaction.SignedTransaction
+				if err = cbor.Unmarshal(raw, &signed); err != nil {
+					return nil, q.fail("Transactions", err)
+				}
+				var tx transaction.Transaction
+				if err = cbor.Unmarshal(signed.Blob, &tx); err != nil {
+					return nil, q.fail("Transactions", err)
+				}
+				list = append(list, &graphQLTransaction{
+					signed: &signed, tx: &tx, result: txs.Results[i]})
+			}
+			return list, nil
+		}},
+		"epoch": {Type: epochType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			conn, err := q.connection()
+			if err != nil {
+				return nil, err
+			}
+			epoch, err := beacon.NewBeaconClient(conn).GetEpoch(q.ctx,
+				source.(*consensus.Block).Height)
+			if err != nil {
+				return nil, q.fail("Epoch", err)
+			}
+			return &graphQLEpoch{epoch: epoch}, nil
+		}},
+	}
+
+	transactionType.Fields = map[string]*graphql.FieldDef{
+		"hash": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).signed.Hash().String()
+		}),
+		"signer": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).signed.Signature.PublicKey
+		}),
+		"sender": accountField(func(s interface{}) staking.Address {
+			return staking.NewAddress(
+				s.(*graphQLTransaction).signed.Signature.PublicKey)
+		}),
+		"method": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).tx.Method
+		}),
+		"nonce": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).tx.Nonce
+		}),
+		"fee": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).tx.Fee
+		}),
+		"success": value(func(s interface{}) interface{} {
+			return s.(*graphQLTransaction).result.IsSuccess()
+		}),
+		"error": value(func(s interface{}) interface{} {
+			result := s.(*graphQLTransaction).result
+			if result.IsSuccess() {
+				return nil
+			}
+			return result.Error
+		}),
+	}
+
+	accountType.Fields = map[string]*graphql.FieldDef{
+		"address": value(func(s interface{}) interface{} {
+			return s.(*graphQLAccount).address
+		}),
+		"balance": accountValue(func(a *staking.Account) interface{} {
+			return a.General.Balance
+		}),
+		"nonce": accountValue(func(a *staking.Account) interface{} {
+			return a.General.Nonce
+		}),
+		"escrowBalance": accountValue(func(a *staking.Account) interface{} {
+			return a.Escrow.Active.Balance
+		}),
+		"escrowShares": accountValue(func(a *staking.Account) interface{} {
+			return a.Escrow.Active.TotalShares
+		}),
+		"debondingBalance": accountValue(func(a *staking.Account) interface{} {
+			return a.Escrow.Debonding.Balance
+		}),
+		"debondingShares": accountValue(func(a *staking.Account) interface{} {
+			return a.Escrow.Debonding.TotalShares
+		}),
+		"commissionSchedule": accountValue(func(a *staking.Account) interface{} {
+			return a.Escrow.CommissionSchedule
+		}),
+		"delegations": {Type: delegationType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			delegations, err := staking.NewStakingClient(conn).DelegationsFor(
+				q.ctx, &staking.OwnerQuery{Height: height,
+					Owner: source.(*graphQLAccount).address})
+			if err != nil {
+				return nil, q.fail("Delegations", err)
+			}
+			// Delegations are listed by escrow address, not in random
+			// order of map
+			list := []*graphQLDelegation{}
+			for address, delegation := range delegations {
+				list = append(list, &graphQLDelegation{
+					escrow: &graphQLAccount{address: address},
+					shares: delegation.Shares,
+				})
+			}
+			sort.Slice(list, func(i, j int) bool {
+				return list[i].escrow.address.String() <
+					list[j].escrow.address.String()
+			})
+			return list, nil
+		}},
+	}
+
+	delegationType.Fields = map[string]*graphql.FieldDef{
+		"shares": value(func(s interface{}) interface{} {
+			return s.(*graphQLDelegation).shares
+		}),
+		"amount": {Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			delegation := source.(*graphQLDelegation)
+			account, err := loadAccount(delegation.escrow)
+			if err != nil {
+				return nil, err
+			}
+			amount, err := account.Escrow.Active.StakeForShares(
+				&delegation.shares)
+			if err != nil {
+				return nil, q.fail("Delegation amount", err)
+			}
+			return amount, nil
+		}},
+		"escrow": {Type: accountType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			return source.(*graphQLDelegation).escrow, nil
+		}},
+	}
+
+	entityType.Fields = map[string]*graphql.FieldDef{
+		"id": value(func(s interface{}) interface{} {
+			return s.(*entity.Entity).ID
+		}),
+		"address": value(func(s interface{}) interface{} {
+			return staking.NewAddress(s.(*entity.Entity).ID)
+		}),
+		"account": accountField(func(s interface{}) staking.Address {
+			return staking.NewAddress(s.(*entity.Entity).ID)
+		}),
+		"nodes": {Type: nodeType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			list := []interface{}{}
+			for _, id := range source.(*entity.Entity).Nodes {
+				nod, err := loadNode(id)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, nod)
+			}
+			return list, nil
+		}},
+	}
+
+	nodeType.Fields = map[string]*graphql.FieldDef{
+		"id": value(func(s interface{}) interface{} {
+			return s.(*node.Node).ID
+		}),
+		"entityId": value(func(s interface{}) interface{} {
+			return s.(*node.Node).EntityID
+		}),
+		"entity": {Type: entityType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			return loadEntity(source.(*node.Node).EntityID)
+		}},
+		"expiration": value(func(s interface{}) interface{} {
+			return s.(*node.Node).Expiration
+		}),
+		"roles": value(func(s interface{}) interface{} {
+			return s.(*node.Node).Roles.String()
+		}),
+		"consensusId": value(func(s interface{}) interface{} {
+			return s.(*node.Node).Consensus.ID
+		}),
+		"runtimes": {Type: runtimeType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			list := []interface{}{}
+			for _, rt := range source.(*node.Node).Runtimes {
+				runtime, err := loadRuntime(rt.ID)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, runtime)
+			}
+			return list, nil
+		}},
+		"status": {Type: nodeStatusType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			status, err := registry.NewRegistryClient(conn).GetNodeStatus(
+				q.ctx, &registry.IDQuery{Height: height,
+					ID: source.(*node.Node).ID})
+			if err != nil {
+				return nil, q.fail("Node Status", err)
+			}
+			return status, nil
+		}},
+	}
+
+	nodeStatusType.Fields = map[string]*graphql.FieldDef{
+		"expirationProcessed": value(func(s interface{}) interface{} {
+			return s.(*registry.NodeStatus).ExpirationProcessed
+		}),
+		"freezeEndTime": value(func(s interface{}) interface{} {
+			return s.(*registry.NodeStatus).FreezeEndTime
+		}),
+		"frozen": value(func(s interface{}) interface{} {
+			return s.(*registry.NodeStatus).IsFrozen()
+		}),
+	}
+
+	runtimeType.Fields = map[string]*graphql.FieldDef{
+		"id": value(func(s interface{}) interface{} {
+			return s.(*registry.Runtime).ID
+		}),
+		"kind": value(func(s interface{}) interface{} {
+			return s.(*registry.Runtime).Kind.String()
+		}),
+		"teeHardware": value(func(s interface{}) interface{} {
+			return s.(*registry.Runtime).TEEHardware.String()
+		}),
+		"entityId": value(func(s interface{}) interface{} {
+			return s.(*registry.Runtime).EntityID
+		}),
+		"entity": {Type: entityType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			return loadEntity(source.(*registry.Runtime).EntityID)
+		}},
+	}
+
+	proposalType.Fields = map[string]*graphql.FieldDef{
+		"id": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).ID
+		}),
+		"submitter": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).Submitter
+		}),
+		"submitterAccount": accountField(func(s interface{}) staking.Address {
+			return s.(*governance.Proposal).Submitter
+		}),
+		"state": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).State.String()
+		}),
+		"deposit": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).Deposit
+		}),
+		"content": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).Content
+		}),
+		"createdAt": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).CreatedAt
+		}),
+		"closesAt": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).ClosesAt
+		}),
+		"invalidVotes": value(func(s interface{}) interface{} {
+			return s.(*governance.Proposal).InvalidVotes
+		}),
+		"votes": {Type: voteType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			votes, err := governance.NewGovernanceClient(conn).Votes(q.ctx,
+				&governance.ProposalQuery{Height: height,
+					ProposalID: source.(*governance.Proposal).ID})
+			if err != nil {
+				return nil, q.fail("Votes", err)
+			}
+			return votes, nil
+		}},
+	}
+
+	voteType.Fields = map[string]*graphql.FieldDef{
+		"voter": value(func(s interface{}) interface{} {
+			return s.(*governance.VoteEntry).Voter
+		}),
+		"voterAccount": accountField(func(s interface{}) staking.Address {
+			return s.(*governance.VoteEntry).Voter
+		}),
+		"vote": value(func(s interface{}) interface{} {
+			return s.(*governance.VoteEntry).Vote.String()
+		}),
+	}
+
+	epochType.Fields = map[string]*graphql.FieldDef{
+		"epoch": value(func(s interface{}) interface{} {
+			return s.(*graphQLEpoch).epoch
+		}),
+		"startHeight": {Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			conn, err := q.connection()
+			if err != nil {
+				return nil, err
+			}
+			height, err := beacon.NewBeaconClient(conn).GetEpochBlock(q.ctx,
+				source.(*graphQLEpoch).epoch)
+			if err != nil {
+				return nil, q.fail("Epoch Block", err)
+			}
+			return height, nil
+		}},
+	}
+
+	queryType := &graphql.Object{Name: "Query"}
+	queryType.Fields = map[string]*graphql.FieldDef{
+		"height": {Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			if _, err := q.consensus(); err != nil {
+				return nil, err
+			}
+			return q.height, nil
+		}},
+		"block": {Type: blockType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			co, err := q.consensus()
+			if err != nil {
+				return nil, err
+			}
+			blk, err := co.GetBlock(q.ctx, q.height)
+			if err != nil {
+				return nil, q.fail("Block", err)
+			}
+			return blk, nil
+		}},
+		"epoch": {Type: epochType, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			epoch, err := beacon.NewBeaconClient(conn).GetEpoch(q.ctx, height)
+			if err != nil {
+				return nil, q.fail("Epoch", err)
+			}
+			return &graphQLEpoch{epoch: epoch}, nil
+		}},
+		"account": {Type: accountType, Args: []*graphql.ArgDef{
+			{Name: "address", Type: "String!"}}, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			text, ok := args.String("address")
+			if !ok {
+				return nil, fmt.Errorf("Argument \"address\" of type " +
+					"String is required")
+			}
+			var address staking.Address
+			if err := address.UnmarshalText([]byte(text)); err != nil {
+				return nil, fmt.Errorf("Failed to UnmarshalText into " +
+					"Address.")
+			}
+			return &graphQLAccount{address: address}, nil
+		}},
+		"entity": {Type: entityType, Args: []*graphql.ArgDef{
+			{Name: "id", Type: "String!"}}, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			id, err := publicKeyArgument(args, "id")
+			if err != nil {
+				return nil, err
+			}
+			return loadEntity(id)
+		}},
+		"entities": {Type: entityType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			entities, err := registry.NewRegistryClient(conn).GetEntities(
+				q.ctx, height)
+			if err != nil {
+				return nil, q.fail("Entities", err)
+			}
+			return entities, nil
+		}},
+		"node": {Type: nodeType, Args: []*graphql.ArgDef{
+			{Name: "id", Type: "String!"}}, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			id, err := publicKeyArgument(args, "id")
+			if err != nil {
+				return nil, err
+			}
+			return loadNode(id)
+		}},
+		"nodes": {Type: nodeType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			nodes, err := registry.NewRegistryClient(conn).GetNodes(q.ctx,
+				height)
+			if err != nil {
+				return nil, q.fail("Nodes", err)
+			}
+			return nodes, nil
+		}},
+		"runtime": {Type: runtimeType, Args: []*graphql.ArgDef{
+			{Name: "id", Type: "String!"}}, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			text, ok := args.String("id")
+			if !ok {
+				return nil, fmt.Errorf("Argument \"id\" of type String " +
+					"is required")
+			}
+			var id common.Namespace
+			if err := id.UnmarshalText([]byte(text)); err != nil {
+				return nil, fmt.Errorf("Failed to UnmarshalText into " +
+					"Namespace.")
+			}
+			return loadRuntime(id)
+		}},
+		"runtimes": {Type: runtimeType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			runtimes, err := registry.NewRegistryClient(conn).GetRuntimes(
+				q.ctx, &registry.GetRuntimesQuery{Height: height,
+					IncludeSuspended: true})
+			if err != nil {
+				return nil, q.fail("Runtimes", err)
+			}
+			return runtimes, nil
+		}},
+		"proposal": {Type: proposalType, Args: []*graphql.ArgDef{
+			{Name: "id", Type: "Int!"}}, Resolve: func(source interface{},
+			args graphql.Arguments) (interface{}, error) {
+			id, ok := args.Int("id")
+			if !ok || id < 0 {
+				return nil, fmt.Errorf("Argument \"id\" of type Int is " +
+					"required")
+			}
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			proposal, err := governance.NewGovernanceClient(conn).Proposal(
+				q.ctx, &governance.ProposalQuery{Height: height,
+					ProposalID: uint64(id)})
+			if err != nil {
+				return nil, q.fail("Proposal", err)
+			}
+			return proposal, nil
+		}},
+		"proposals": {Type: proposalType, List: true, Resolve: func(
+			source interface{}, args graphql.Arguments) (interface{}, error) {
+			height, conn, err := q.at()
+			if err != nil {
+				return nil, err
+			}
+			proposals, err := governance.NewGovernanceClient(conn).Proposals(
+				q.ctx, height)
+			if err != nil {
+				return nil, q.fail("Proposals", err)
+			}
+			return proposals, nil
+		}},
+	}
+
+	return &graphql.Schema{Query: queryType, MaxDepth: graphQLMaxDepth,
+		MaxComplexity: graphQLMaxComplexity}
+}
+
+// GraphQL answers GraphQL queries over consensus, staking, registry and
+// governance data of node, read at height given in request or at latest
+// height. Only the fields selected are retrieved from the node.
+func GraphQL(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if confirmation == false {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(&graphql.Result{Errors: []*graphql.Error{
+			{Message: "Node name requested doesn't exist"}}})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(&graphql.Result{Errors: []*graphql.Error{
+			{Message: "Unexepcted value found, height needs to be " +
+				"string of int!"}}})
+		return
+	}
+
+	// Queries are read from body of POST requests, or from query of GET
+	// requests
+	var request graphQLRequest
+	if r.Method == http.MethodPost {
+		body := http.MaxBytesReader(w, r.Body, graphQLMaxBodyBytes)
+		if err := json.NewDecoder(body).Decode(&request); err != nil {
+			json.NewEncoder(w).Encode(&graphql.Result{Errors: []*graphql.Error{
+				{Message: "Failed to decode GraphQL request body!"}}})
+			return
+		}
+	} else {
+		request.Query = r.URL.Query().Get("query")
+		request.OperationName = r.URL.Query().Get("operationName")
+		if variables := r.URL.Query().Get("variables"); len(variables) > 0 {
+			if err := json.Unmarshal([]byte(variables),
+				&request.Variables); err != nil {
+				json.NewEncoder(w).Encode(&graphql.Result{
+					Errors: []*graphql.Error{{Message: "Failed to decode " +
+						"GraphQL variables!"}}})
+				return
+			}
+		}
+	}
+
+	q := &graphQLQuery{ctx: r.Context(), socket: socket, height: height}
+	defer q.close()
+
+	result := graphql.Execute(newGraphQLSchema(q), request.Query,
+		request.Variables, request.OperationName)
+	if len(result.Errors) > 0 {
+		lgr.Warning.Println("Request at /graphql answered with errors : ",
+			result.Errors[0].Message)
+	} else {
+		lgr.Info.Println("Request at /graphql responding with query result!")
+	}
+
+	// Queries with fields rejected by call limits are answered with HTTP
+	// 429, fields retrieved are still returned
+	if q.limited {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+	json.NewEncoder(w).Encode(result)
+}
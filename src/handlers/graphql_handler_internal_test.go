@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+)
+
+func Test_GraphQLQuery_Fail(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+		limited bool
+	}{
+		{"backend error", errors.New("unavailable"),
+			"Failed to retrieve Account!", false},
+		{"call limit", rpc.ErrCallLimit,
+			"Too many requests to node, retry later!", true},
+		{"wrapped call limit", fmt.Errorf("call: %w", rpc.ErrCallLimit),
+			"Too many requests to node, retry later!", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := &graphQLQuery{}
+			err := q.fail("Account", test.err)
+			if err.Error() != test.message {
+				t.Errorf("Unexpected error got %v want %v", err,
+					test.message)
+			}
+			if q.limited != test.limited {
+				t.Errorf("Unexpected limited got %v want %v", q.limited,
+					test.limited)
+			}
+		})
+	}
+}
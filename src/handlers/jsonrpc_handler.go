@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+)
+
+// Standard JSON-RPC 2.0 error codes, server errors are returned by handlers
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+	rpcServerError    = -32000
+)
+
+// rpcMethods maps JSON-RPC methods to the routes answering them, built
+// from route table on first use
+var (
+	rpcMethods     map[string]string
+	rpcMethodsOnce sync.Once
+)
+
+// rpcMethodPath returns path of route answering JSON-RPC method
+func rpcMethodPath(method string) (string, bool) {
+	rpcMethodsOnce.Do(func() {
+		rpcMethods = map[string]string{}
+		for _, route := range Routes() {
+			if len(route.RPCMethod) > 0 {
+				rpcMethods[route.RPCMethod] = route.Path
+			}
+		}
+	})
+	path, ok := rpcMethods[method]
+	return path, ok
+}
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcError builds JSON-RPC response holding error
+func rpcError(id json.RawMessage, code int,
+	message string) *responses.RPCResponse {
+
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &responses.RPCResponse{
+		JSONRPC: "2.0",
+		Error:   &responses.RPCError{Code: code, Message: message},
+		ID:      id,
+	}
+}
+
+// rpcParams converts params object into query of route
+func rpcParams(params json.RawMessage) (url.Values, bool) {
+	query := url.Values{}
+	if len(params) == 0 || string(params) == "null" {
+		return query, true
+	}
+
+	// Numbers are kept as written so that large heights and ids aren't
+	// turned into exponent notation
+	values := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, false
+	}
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			query.Set(key, v)
+		case json.Number:
+			query.Set(key, v.String())
+		case bool:
+			query.Set(key, strconv.FormatBool(v))
+		default:
+			return nil, false
+		}
+	}
+	return query, true
+}
+
+// callRPC answers single JSON-RPC request through route of its method,
+// returning nil for notifications
+func callRPC(r *http.Request, router http.Handler,
+	raw json.RawMessage) *responses.RPCResponse {
+
+	var request rpcRequest
+	if err := json.Unmarshal(raw, &request); err != nil ||
+		request.JSONRPC != "2.0" || len(request.Method) == 0 {
+		return rpcError(request.ID, rpcInvalidRequest, "Invalid Request")
+	}
+	notification := len(request.ID) == 0
+
+	response := func() *responses.RPCResponse {
+		path, ok := rpcMethodPath(request.Method)
+		if !ok {
+			return rpcError(request.ID, rpcMethodNotFound,
+				"Method not found")
+		}
+
+		query, ok := rpcParams(request.Params)
+		if !ok {
+			return rpcError(request.ID, rpcInvalidParams,
+				"Invalid params, params need to be an object of "+
+					"strings, numbers or booleans")
+		}
+
+		rec, err := serveInternal(r.Context(), router, http.MethodGet,
+			path, query)
+		// Routes the API key may not access, or limited routes, answer
+		// with an error object
+		if err != nil || (rec.status != http.StatusOK &&
+			rec.status != http.StatusForbidden &&
+			rec.status != http.StatusTooManyRequests) {
+			return rpcError(request.ID, rpcInternalError, "Internal error")
+		}
+
+		// Handlers answer with either an error or a result object
+		body := map[string]json.RawMessage{}
+		if err = json.Unmarshal(rec.body.Bytes(), &body); err != nil {
+			return rpcError(request.ID, rpcInternalError, "Internal error")
+		}
+		if message, ok := body["error"]; ok {
+			var text string
+			json.Unmarshal(message, &text)
+			return rpcError(request.ID, rpcServerError, text)
+		}
+		result, ok := body["result"]
+		if !ok {
+			result, ok = body["results"]
+		}
+		if !ok {
+			result = json.RawMessage(rec.body.Bytes())
+		}
+		return &responses.RPCResponse{
+			JSONRPC: "2.0",
+			Result:  result,
+			ID:      request.ID,
+		}
+	}()
+
+	if notification {
+		return nil
+	}
+	return response
+}
+
+// JSONRPC returns handler answering JSON-RPC 2.0 requests, and batches of
+// them, through routes of router.
+func JSONRPC(router http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		// Add header so that received knows they're receiving JSON
+		w.Header().Add("Content-Type", "application/json")
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			json.NewEncoder(w).Encode(rpcError(nil, rpcParseError,
+				"Parse error"))
+			return
+		}
+
+		// Single request
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 ||
+			trimmed[0] != '[' {
+			response := callRPC(r, router, raw)
+			if response == nil {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			lgr.Info.Println("Request at /rpc responding to method!")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		// Batch of requests
+		var batch []json.RawMessage
+		if err := json.Unmarshal(raw, &batch); err != nil {
+			json.NewEncoder(w).Encode(rpcError(nil, rpcParseError,
+				"Parse error"))
+			return
+		}
+		if len(batch) == 0 {
+			json.NewEncoder(w).Encode(rpcError(nil, rpcInvalidRequest,
+				"Invalid Request"))
+			return
+		}
+
+		results := []*responses.RPCResponse{}
+		for _, request := range batch {
+			if response := callRPC(r, router, request); response != nil {
+				results = append(results, response)
+			}
+		}
+		if len(results) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		lgr.Info.Println("Request at /rpc responding to batch!")
+		json.NewEncoder(w).Encode(results)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+// rpcRouter answers ping the same way the API router does
+func rpcRouter() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ping", hdl.Pong)
+	return mux
+}
+
+func Test_JSONRPC_ParseError(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(`{`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.JSONRPC(rpcRouter())
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_JSONRPC_MethodNotFound(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(
+		`{"jsonrpc":"2.0","method":"unicorn.get","id":1}`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.JSONRPC(rpcRouter())
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"jsonrpc":"2.0","error":{"code":-32601,"message":"Method not found"},"id":1}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_JSONRPC_Batch(t *testing.T) {
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(
+		`[{"jsonrpc":"2.0","method":"general.ping","id":"a"},`+
+			`{"jsonrpc":"2.0","method":"general.ping"},`+
+			`{"jsonrpc":"2.0","method":"general.ping","params":[1],"id":2}]`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.JSONRPC(rpcRouter())
+	handler.ServeHTTP(rr, req)
+
+	expected := `[{"jsonrpc":"2.0","result":"pong","id":"a"},` +
+		`{"jsonrpc":"2.0","error":{"code":-32602,"message":"Invalid params, params need to be an object of strings, numbers or booleans"},"id":2}]`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+// echoQuery answers with query it received as result
+func echoQuery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"result": r.URL.Query()})
+}
+
+func Test_JSONRPC_NumericParams(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/governance/proposal", echoQuery)
+
+	req, _ := http.NewRequest("POST", "/rpc", strings.NewReader(
+		`{"jsonrpc":"2.0","method":"governance.getProposal",`+
+			`"params":{"name":"Oasis_Local","height":5000000,"id":12345678},`+
+			`"id":1}`))
+
+	rr := httptest.NewRecorder()
+	handler := hdl.JSONRPC(mux)
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"jsonrpc":"2.0","result":{"height":["5000000"],` +
+		`"id":["12345678"],"name":["Oasis_Local"]},"id":1}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
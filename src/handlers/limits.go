@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SimplyVC/oasis_api_server/src/config"
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+)
+
+// maxBuckets is number of clients after which buckets of idle clients are
+// dropped
+const maxBuckets = 10000
+
+// RateLimit is a token bucket rate limit, clients may send Burst requests at
+// once and Rate requests per second after that
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// tokenBucket holds tokens left to client and time they were counted at
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Rate limit of clients without their own, and buckets of every client
+var (
+	rateMutex   sync.Mutex
+	defaultRate RateLimit
+	buckets     = map[string]*tokenBucket{}
+)
+
+// ParseRateLimit returns rate limit of given rate and burst, burst defaults
+// to rate rounded up to at least one request
+func ParseRateLimit(rate string, burst string) (RateLimit, error) {
+	limit := RateLimit{}
+	var err error
+	if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil ||
+		limit.Rate < 0 {
+		return limit, errors.New("rate needs to be a positive number")
+	}
+	limit.Burst = math.Max(1, math.Ceil(limit.Rate))
+	if len(burst) > 0 {
+		if limit.Burst, err = strconv.ParseFloat(burst, 64); err != nil ||
+			limit.Burst < 1 {
+			return limit, errors.New("burst needs to be at least 1")
+		}
+	}
+	return limit, nil
+}
+
+// SetRateLimit sets rate limit of clients whose API key sets none, clients
+// are limited by API key or else by IP address. Rate of 0 disables it.
+func SetRateLimit(limit RateLimit) {
+	rateMutex.Lock()
+	defer rateMutex.Unlock()
+	defaultRate = limit
+	buckets = map[string]*tokenBucket{}
+}
+
+// takeToken takes token of client from its bucket, returning time until a
+// token is available if bucket is empty
+func takeToken(client string, limit RateLimit,
+	now time.Time) (bool, time.Duration) {
+
+	rateMutex.Lock()
+	defer rateMutex.Unlock()
+
+	bucket, ok := buckets[client]
+	if !ok {
+		// Drop buckets that refilled completely, their clients are idle
+		if len(buckets) >= maxBuckets {
+			for name, b := range buckets {
+				if b.tokens+now.Sub(b.last).Seconds()*limit.Rate >=
+					limit.Burst {
+					delete(buckets, name)
+				}
+			}
+		}
+		bucket = &tokenBucket{tokens: limit.Burst, last: now}
+		buckets[client] = bucket
+	}
+
+	bucket.tokens = math.Min(limit.Burst,
+		bucket.tokens+now.Sub(bucket.last).Seconds()*limit.Rate)
+	bucket.last = now
+	if bucket.tokens < 1 {
+		wait := (1 - bucket.tokens) / limit.Rate
+		return false, time.Duration(wait * float64(time.Second))
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+// tooManyRequests answers request with HTTP 429, asking to retry after
+// wait rounded up to whole seconds
+func tooManyRequests(w http.ResponseWriter, wait time.Duration,
+	message string) {
+
+	seconds := int(math.Ceil(wait.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(responses.ErrorResponse{Error: message})
+}
+
+// callLimited answers request with HTTP 429 if err rejected call to node
+// because too many calls were outstanding, returning whether it did. Calls
+// can be rejected even though CallLimits let request through, as other
+// requests may have called the node meanwhile.
+func callLimited(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, rpc.ErrCallLimit) {
+		return false
+	}
+	tooManyRequests(w, time.Second, "Too many requests to node, retry later!")
+	return true
+}
+
+// RateLimits limits rate of requests of each client, identified by API key
+// or else by IP address. Requests over limit are answered with HTTP 429.
+// Internal requests made through router aren't limited.
+func RateLimits(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if internal, _ := r.Context().Value(
+			internalContext{}).(bool); internal {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rateMutex.Lock()
+		limit := defaultRate
+		rateMutex.Unlock()
+
+		client, label := "", "anonymous"
+		if key, ok := r.Context().Value(apiKeyContext{}).(*APIKey); ok {
+			client, label = "key:"+key.Name, key.Name
+			if key.RateLimit.Rate > 0 {
+				limit = key.RateLimit
+			}
+		} else {
+			host, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				host = r.RemoteAddr
+			}
+			client = "ip:" + host
+		}
+		if limit.Rate <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ok, wait := takeToken(client, limit, time.Now())
+		if !ok {
+			lgr.Warning.Printf("Request of %s at %s rate limited", client,
+				r.URL.Path)
+			metrics.RateLimited.WithLabelValues(label).Inc()
+			tooManyRequests(w, wait, "Rate limit exceeded, retry later!")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CallLimits answers requests for nodes that already have as many gRPC
+// calls outstanding as allowed with HTTP 429, instead of queueing them on
+// the internal socket of node.
+func CallLimits(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		for _, node := range config.GetNodes() {
+			if node["node_name"] != name {
+				continue
+			}
+			if !rpc.CallsAvailable(node["isocket_path"]) {
+				lgr.Warning.Printf("Request at %s rejected, too many "+
+					"calls outstanding to node %s", r.URL.Path, name)
+				metrics.CallsRejected.WithLabelValues(name).Inc()
+				tooManyRequests(w, time.Second, "Too many requests to "+
+					"node "+name+", retry later!")
+				return
+			}
+			break
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,73 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+func Test_RateLimits_Exceeded(t *testing.T) {
+	hdl.SetRateLimit(hdl.RateLimit{Rate: 0.5, Burst: 2})
+	defer hdl.SetRateLimit(hdl.RateLimit{})
+
+	handler := hdl.RateLimits(http.HandlerFunc(hdl.Pong))
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("GET", "/api/ping", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		if i < 2 {
+			if status := rr.Code; status != http.StatusOK {
+				t.Errorf("handler returned wrong status code: got %v "+
+					"want %v", status, http.StatusOK)
+			}
+			continue
+		}
+		if status := rr.Code; status != http.StatusTooManyRequests {
+			t.Errorf("handler returned wrong status code: got %v want %v",
+				status, http.StatusTooManyRequests)
+		}
+		if retry := rr.Header().Get("Retry-After"); retry != "2" {
+			t.Errorf("handler returned unexpected Retry-After: got %v "+
+				"want %v", retry, "2")
+		}
+
+		expected := `{"error":"Rate limit exceeded, retry later!"}`
+		if strings.TrimSpace(rr.Body.String()) != expected {
+			t.Errorf("handler returned unexpected body: got %v want %v",
+				rr.Body.String(), expected)
+		}
+	}
+
+	// Other clients have buckets of their own
+	req, _ := http.NewRequest("GET", "/api/ping", nil)
+	req.RemoteAddr = "192.0.2.2:1234"
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+}
+
+func Test_ParseRateLimit(t *testing.T) {
+	limit, err := hdl.ParseRateLimit("2.5", "")
+	if err != nil || limit.Rate != 2.5 || limit.Burst != 3 {
+		t.Errorf("ParseRateLimit returned unexpected limit: got %v %v "+
+			"want rate 2.5 and burst 3", limit, err)
+	}
+
+	for _, invalid := range [][2]string{{"", ""}, {"-1", ""},
+		{"1", "0"}, {"1", "many"}} {
+		if _, err := hdl.ParseRateLimit(invalid[0], invalid[1]); err == nil {
+			t.Errorf("ParseRateLimit accepted rate %q and burst %q",
+				invalid[0], invalid[1])
+		}
+	}
+}
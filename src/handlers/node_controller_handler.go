@@ -4,13 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 
+	"github.com/SimplyVC/oasis_api_server/src/config"
 	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
 	"github.com/SimplyVC/oasis_api_server/src/responses"
 	"github.com/SimplyVC/oasis_api_server/src/rpc"
+	common_namespace "github.com/oasisprotocol/oasis-core/go/common"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
 // loadNodeControllerClient loads node controller client and returns it
@@ -64,6 +71,9 @@ func GetIsSynced(w http.ResponseWriter, r *http.Request) {
 	// Retrieving synchronized state from node controller client
 	synced, err := nc.IsSynced(context.Background())
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get IsSynced!"})
 		lgr.Error.Println("Request at /api/nodecontroller/synced "+
@@ -76,3 +86,309 @@ func GetIsSynced(w http.ResponseWriter, r *http.Request) {
 		" IsSynced State!")
 	json.NewEncoder(w).Encode(responses.IsSyncedResponse{Synced: synced})
 }
+
+// GetControlStatus returns status overview of node including its identity,
+// consensus, registration, runtimes and key manager status.
+func GetControlStatus(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Attempt to load connection with node controller client
+	connection, nc := loadNodeControllerClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if nc == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieving status overview from node controller client
+	status, err := nc.GetStatus(context.Background())
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Status!"})
+		lgr.Error.Println("Request at /api/nodecontroller/status "+
+			"failed to get Status : ", err)
+		return
+	}
+
+	// Node doesn't report key manager status separately, it is the status
+	// of runtimes registered as key managers
+	keyManagers := map[common_namespace.Namespace]control.RuntimeStatus{}
+	for id, runtime := range status.Runtimes {
+		if runtime.Descriptor != nil &&
+			runtime.Descriptor.Kind == registry.KindKeyManager {
+			keyManagers[id] = runtime
+		}
+	}
+
+	// Responding with retrieved status overview above
+	lgr.Info.Println("Request at /api/nodecontroller/status responding " +
+		"with Status!")
+	json.NewEncoder(w).Encode(responses.ControlStatusResponse{
+		Status: &responses.ControlStatus{
+			Status:      status,
+			KeyManagers: keyManagers,
+		}})
+}
+
+// Time waited for node to become ready if no timeout is specified
+const defaultWaitTimeout = 10 * time.Minute
+
+// Longest time waited for node to become ready, longer timeouts requested
+// are clamped to it
+var (
+	maxWaitTimeoutMutex sync.RWMutex
+	maxWaitTimeout      = time.Hour
+)
+
+// SetMaxWaitTimeout sets longest time requests may wait for node to become
+// ready, as each wait keeps connections to node open.
+func SetMaxWaitTimeout(timeout time.Duration) {
+	maxWaitTimeoutMutex.Lock()
+	defer maxWaitTimeoutMutex.Unlock()
+	maxWaitTimeout = timeout
+}
+
+// waitTimeout returns time to wait for node to become ready given timeout
+// in seconds requested, 0 if none was, clamped to longest time allowed
+func waitTimeout(timeout int64) time.Duration {
+	maxWaitTimeoutMutex.RLock()
+	limit := maxWaitTimeout
+	maxWaitTimeoutMutex.RUnlock()
+
+	wait := defaultWaitTimeout
+	if timeout > 0 {
+		if timeout > int64(limit/time.Second) {
+			return limit
+		}
+		wait = time.Duration(timeout) * time.Second
+	}
+	if wait > limit {
+		wait = limit
+	}
+	return wait
+}
+
+// Interval at which progress is streamed while waiting for node
+const waitProgressInterval = 5 * time.Second
+
+// referenceSocket returns socket of node used to learn network height, the
+// requested reference node or else first other configured node API key of
+// request may query.
+func referenceSocket(r *http.Request, nodeName string,
+	reference string) (bool, string) {
+
+	if len(reference) > 0 {
+		return checkNodeName(reference)
+	}
+
+	names := []string{}
+	sockets := map[string]string{}
+	for _, node := range config.GetNodes() {
+		if node["node_name"] != nodeName &&
+			requestAllowsNode(r, node["node_name"]) {
+			names = append(names, node["node_name"])
+			sockets[node["node_name"]] = node["isocket_path"]
+		}
+	}
+	if len(names) == 0 {
+		return false, ""
+	}
+	sort.Strings(names)
+	return true, sockets[names[0]]
+}
+
+// latestHeight returns latest block height known to node, or 0 if it
+// can't be retrieved.
+func latestHeight(co consensus.ClientBackend) int64 {
+	if co == nil {
+		return 0
+	}
+	status, err := co.GetStatus(context.Background())
+	if err != nil {
+		lgr.Error.Println("Failed to retrieve consensus Status : ", err)
+		return 0
+	}
+	return status.LatestHeight
+}
+
+// WaitReady blocks until node is synced and ready or timeout in seconds
+// expires, streaming node height against network height in the meantime.
+func WaitReady(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving timeout in seconds from query request
+	recvTimeout := r.URL.Query().Get("timeout")
+	timeout := checkAmount(recvTimeout)
+	if timeout == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, timeout needs to be " +
+				"a string representing an int!"})
+		return
+	}
+	wait := waitTimeout(timeout)
+
+	// Retrieving node used to learn network height from query request
+	reference := r.URL.Query().Get("reference")
+	if len(reference) > 0 && !requestAllowsNode(r, reference) {
+
+		// Stop code here no need to establish connection and reply
+		denyRequest(w, http.StatusForbidden, "API key is not allowed to "+
+			"access node "+reference+"!")
+		return
+	}
+	hasReference, refSocket := referenceSocket(r, nodeName, reference)
+	if len(reference) > 0 && !hasReference {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Reference node name requested doesn't exist"})
+		return
+	}
+
+	// Attempt to load connection with node controller client
+	connection, nc := loadNodeControllerClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if nc == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with consensus client
+	consensusConnection, co := loadConsensusClient(socket)
+
+	// Close connection once code underneath executes
+	defer consensusConnection.Close()
+
+	// If null object was retrieved send response
+	if co == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Network height is only reported if reference node can be reached
+	var refCo consensus.ClientBackend
+	if hasReference {
+		refConnection, refClient := loadConsensusClient(refSocket)
+		if refClient != nil {
+			defer refConnection.Close()
+			refCo = refClient
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), wait)
+	defer cancel()
+
+	// Wait for node in background while progress is streamed
+	synced := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		if err := nc.WaitSync(ctx); err != nil {
+			done <- err
+			return
+		}
+		close(synced)
+		done <- nc.WaitReady(ctx)
+	}()
+
+	progress := func() *responses.WaitProgress {
+		p := &responses.WaitProgress{
+			Height:        latestHeight(co),
+			NetworkHeight: latestHeight(refCo),
+		}
+		select {
+		case <-synced:
+			p.Synced = true
+		default:
+		}
+		return p
+	}
+
+	lgr.Info.Println("Request at /api/nodecontroller/waitready waiting " +
+		"for node to be ready!")
+	ticker := time.NewTicker(waitProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-done:
+			if err == context.DeadlineExceeded ||
+				ctx.Err() == context.DeadlineExceeded {
+				streamJSON(w, responses.ErrorResponse{
+					Error: "Timed out waiting for node to be ready!"})
+				lgr.Error.Println("Request at /api/nodecontroller/" +
+					"waitready timed out waiting for node to be ready!")
+				return
+			}
+			if err != nil {
+				streamJSON(w, responses.ErrorResponse{
+					Error: "Failed to wait for node to be ready!"})
+				lgr.Error.Println("Request at /api/nodecontroller/"+
+					"waitready failed to wait for node : ", err)
+				return
+			}
+
+			// Responding with final progress once node is ready
+			final := progress()
+			final.Synced = true
+			final.Ready = true
+			lgr.Info.Println("Request at /api/nodecontroller/waitready " +
+				"responding with node ready!")
+			streamJSON(w, responses.WaitProgressResponse{
+				Progress: final})
+			return
+		case <-ticker.C:
+			streamJSON(w, responses.WaitProgressResponse{
+				Progress: progress()})
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_WaitTimeout(t *testing.T) {
+	SetMaxWaitTimeout(time.Hour)
+	defer SetMaxWaitTimeout(time.Hour)
+
+	tests := []struct {
+		name    string
+		timeout int64
+		want    time.Duration
+	}{
+		{"Default", 0, defaultWaitTimeout},
+		{"Requested", 30, 30 * time.Second},
+		{"AtMaximum", 3600, time.Hour},
+		{"AboveMaximum", 3601, time.Hour},
+		{"Overflowing", math.MaxInt64, time.Hour},
+	}
+	for _, test := range tests {
+		if got := waitTimeout(test.timeout); got != test.want {
+			t.Errorf("%s: waitTimeout(%d) returned %v want %v",
+				test.name, test.timeout, got, test.want)
+		}
+	}
+
+	// Default wait is clamped to lower maximum too
+	SetMaxWaitTimeout(time.Minute)
+	if got := waitTimeout(0); got != time.Minute {
+		t.Errorf("waitTimeout(0) returned %v want %v", got, time.Minute)
+	}
+}
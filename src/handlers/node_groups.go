@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SimplyVC/oasis_api_server/src/config"
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+)
+
+// Strategies used to choose member of node group answering a request
+const (
+	StrategyRoundRobin   = "round_robin"
+	StrategyLeastLatency = "least_latency"
+)
+
+// BackendHeader is the response header naming node that answered request
+// sent to a node group
+const BackendHeader = "X-Oasis-Backend"
+
+// memberHealth holds result of last health check of node group member
+type memberHealth struct {
+	healthy bool
+	height  int64
+	latency time.Duration
+}
+
+// Health of node group members and round robin position of each group
+var (
+	groupMutex    sync.RWMutex
+	groupHealth   = map[string]*memberHealth{}
+	groupNext     = map[string]int{}
+	groupStrategy = StrategyRoundRobin
+)
+
+// nodeGroups returns members of every node group configured through the
+// node_group key of node entries.
+func nodeGroups() map[string][]string {
+	groups := map[string][]string{}
+	for _, node := range config.GetNodes() {
+		if group := node["node_group"]; len(group) > 0 {
+			groups[group] = append(groups[group], node["node_name"])
+		}
+	}
+	for _, members := range groups {
+		sort.Strings(members)
+	}
+	return groups
+}
+
+// checkMemberHealth checks whether node is reachable and synced and
+// measures latency of retrieving its status. Nodes that don't answer
+// within timeout are unhealthy.
+func checkMemberHealth(socket string, timeout time.Duration) *memberHealth {
+	health := &memberHealth{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Attempt to load connection with consensus client
+	connection, co := loadConsensusClient(socket)
+	if co == nil {
+		return health
+	}
+	defer connection.Close()
+
+	start := time.Now()
+	status, err := co.GetStatus(ctx)
+	if err != nil {
+		return health
+	}
+	health.latency = time.Since(start)
+	health.height = status.LatestHeight
+
+	// Attempt to load connection with node controller client
+	ncConnection, nc := loadNodeControllerClient(socket)
+	if nc == nil {
+		return health
+	}
+	defer ncConnection.Close()
+
+	synced, err := nc.IsSynced(ctx)
+	health.healthy = err == nil && synced
+	return health
+}
+
+// checkGroupHealth checks all configured nodes within timeout, recording
+// their latest height, and ejects node group members that are unreachable,
+// not synced or behind group by more than maxLag blocks.
+func checkGroupHealth(maxLag int64, timeout time.Duration) {
+	health := map[string]*memberHealth{}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, node := range config.GetNodes() {
+		wg.Add(1)
+		go func(name string, socket string) {
+			defer wg.Done()
+			result := checkMemberHealth(socket, timeout)
+			if result.height > 0 {
+				metrics.LatestHeight.WithLabelValues(name).Set(
+					float64(result.height))
+			}
+			mutex.Lock()
+			health[name] = result
+			mutex.Unlock()
+		}(node["node_name"], node["isocket_path"])
+	}
+	wg.Wait()
+
+	for group, members := range nodeGroups() {
+		var maxHeight int64
+		for _, member := range members {
+			if health[member].height > maxHeight {
+				maxHeight = health[member].height
+			}
+		}
+		for _, member := range members {
+			if health[member].healthy &&
+				maxHeight-health[member].height > maxLag {
+				health[member].healthy = false
+			}
+			if !health[member].healthy {
+				lgr.Warning.Printf("Node %s of group %s is unhealthy, "+
+					"ejecting it from group!", member, group)
+			}
+		}
+	}
+
+	groupMutex.Lock()
+	groupHealth = health
+	groupMutex.Unlock()
+}
+
+// StartGroupHealthChecks sets strategy used to choose group members and
+// checks health of configured nodes at given interval.
+func StartGroupHealthChecks(strategy string, interval time.Duration,
+	maxLag int64) {
+
+	groupMutex.Lock()
+	groupStrategy = strategy
+	groupMutex.Unlock()
+
+	if len(config.GetNodes()) == 0 {
+		return
+	}
+
+	// Checks are bounded by interval so that a hung node can't delay
+	// checks of other nodes
+	go func() {
+		for {
+			checkGroupHealth(maxLag, interval)
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// selectGroupMember chooses healthy member of group to answer a request.
+// Members that haven't been checked yet are assumed to be healthy.
+func selectGroupMember(members []string, group string) (string, bool) {
+	groupMutex.Lock()
+	defer groupMutex.Unlock()
+
+	healthy := []string{}
+	for _, member := range members {
+		if health, ok := groupHealth[member]; !ok || health.healthy {
+			healthy = append(healthy, member)
+		}
+	}
+	if len(healthy) == 0 {
+		return "", false
+	}
+
+	if groupStrategy == StrategyLeastLatency {
+		best := healthy[0]
+		for _, member := range healthy[1:] {
+			health, ok := groupHealth[member]
+			bestHealth, bestOk := groupHealth[best]
+			if ok && (!bestOk || health.latency < bestHealth.latency) {
+				best = member
+			}
+		}
+		return best, true
+	}
+
+	next := groupNext[group] % len(healthy)
+	groupNext[group] = next + 1
+	return healthy[next], true
+}
+
+// NodeGroups resolves node group requested through name query parameter
+// into a healthy member of the group, naming member that answered in the
+// response header. Requests for single nodes are passed on as they are.
+func NodeGroups(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		members, ok := nodeGroups()[name]
+		if len(name) == 0 || !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Node names take precedence over group names
+		if found, _ := checkNodeName(name); found {
+			w.Header().Set(BackendHeader, name)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		member, ok := selectGroupMember(members, name)
+		if !ok {
+			w.Header().Add("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "No healthy node available in group " + name})
+			lgr.Error.Printf("Request for group %s failed, no healthy "+
+				"node available!", name)
+			return
+		}
+
+		query := r.URL.Query()
+		query.Set("name", member)
+		r.URL.RawQuery = query.Encode()
+		w.Header().Set(BackendHeader, member)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,37 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+func Test_NodeGroups_UnknownName(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/ping", nil)
+	q := req.URL.Query()
+	q.Add("name", "Unicorn")
+	req.URL.RawQuery = q.Encode()
+
+	rr := httptest.NewRecorder()
+	handler := hdl.NodeGroups(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.URL.Query().Get("name")))
+		}))
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+
+	if rr.Body.String() != "Unicorn" {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), "Unicorn")
+	}
+
+	if backend := rr.Header().Get(hdl.BackendHeader); backend != "" {
+		t.Errorf("handler returned unexpected backend: got %v want none",
+			backend)
+	}
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+)
+
+// Version of OpenAPI specification generated
+const openAPIVersion = "3.0.3"
+
+// Types described specially in schemas
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// schemaBuilder describes Go types as OpenAPI schemas, named structs are
+// described once in components and referenced from elsewhere
+type schemaBuilder struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+	taken      map[string]bool
+}
+
+// componentName returns name of struct in components, qualified by its
+// package so that types of different packages don't clash
+func (b *schemaBuilder) componentName(t reflect.Type) string {
+	if name, ok := b.names[t]; ok {
+		return name
+	}
+
+	// Oasis packages are mostly named api, use their parent directory
+	pkg := path.Base(t.PkgPath())
+	if pkg == "api" {
+		pkg = path.Base(path.Dir(t.PkgPath()))
+	}
+	name := pkg + "." + t.Name()
+	for i := 2; b.taken[name]; i++ {
+		name = pkg + "." + t.Name() + strconv.Itoa(i)
+	}
+	b.taken[name] = true
+	b.names[t] = name
+	return name
+}
+
+// schema returns schema of values of type
+func (b *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == rawMessageType || t.Kind() == reflect.Interface:
+		return map[string]interface{}{}
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Implements(textMarshalerType) ||
+		reflect.PtrTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	case t.Implements(jsonMarshalerType) ||
+		reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16,
+		reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array",
+			"items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object",
+			"additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if len(t.Name()) == 0 {
+			return b.object(t)
+		}
+		name := b.componentName(t)
+		if _, ok := b.components[name]; !ok {
+			// Reserve name first so that recursive types terminate
+			b.components[name] = nil
+			b.components[name] = b.object(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// object returns schema of struct, with fields of embedded structs inlined
+// as encoding/json does
+func (b *schemaBuilder) object(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	b.fields(t, properties)
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+// fields adds JSON encoded fields of struct to properties
+func (b *schemaBuilder) fields(t reflect.Type,
+	properties map[string]interface{}) {
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && len(name) == 0 &&
+			fieldType.Kind() == reflect.Struct &&
+			!fieldType.Implements(jsonMarshalerType) &&
+			!reflect.PtrTo(fieldType).Implements(jsonMarshalerType) {
+			b.fields(fieldType, properties)
+			continue
+		}
+		if len(field.PkgPath) > 0 {
+			continue
+		}
+		if len(name) == 0 {
+			name = field.Name
+		}
+		properties[name] = b.schema(field.Type)
+	}
+}
+
+// operation returns OpenAPI operation of route answered by method
+func (b *schemaBuilder) operation(route *Route,
+	method string) map[string]interface{} {
+
+	parameters := []interface{}{}
+	for _, param := range route.Parameters {
+		parameters = append(parameters, map[string]interface{}{
+			"name":        param.Name,
+			"in":          "query",
+			"description": param.Description,
+			"required":    param.Required,
+			"schema":      map[string]interface{}{"type": param.Type},
+		})
+	}
+
+	response := map[string]interface{}{}
+	if route.Response != nil {
+		response = b.schema(reflect.TypeOf(route.Response))
+	}
+	contentType := "application/json"
+	if StreamingPaths[route.Path] {
+		contentType = "application/x-ndjson"
+	}
+	if len(route.ContentType) > 0 {
+		contentType = route.ContentType
+	}
+
+	operation := map[string]interface{}{
+		"summary": route.Summary,
+		"tags":    []string{route.Group},
+		"operationId": strings.ToLower(method) + strings.NewReplacer(
+			"/", "_", ".", "_").Replace(route.Path),
+		"parameters": parameters,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Result, or error message in error field",
+				"content": map[string]interface{}{
+					contentType: map[string]interface{}{"schema": response},
+				},
+			},
+		},
+	}
+	if route.Body != nil && method == http.MethodPost {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": b.schema(reflect.TypeOf(route.Body)),
+				},
+			},
+		}
+	}
+	if len(route.RPCMethod) > 0 {
+		operation["x-jsonrpc-method"] = route.RPCMethod
+	}
+	scopes, _ := requiredScopes(route.Path)
+	operation["x-api-key-scopes"] = scopes
+	return operation
+}
+
+// OpenAPISpec returns OpenAPI 3 specification of routes
+func OpenAPISpec(routes []*Route) map[string]interface{} {
+	b := &schemaBuilder{
+		components: map[string]interface{}{},
+		names:      map[reflect.Type]string{},
+		taken:      map[string]bool{},
+	}
+
+	paths := map[string]interface{}{}
+	for _, route := range routes {
+		item := map[string]interface{}{}
+		for _, method := range route.Methods {
+			item[strings.ToLower(method)] = b.operation(route, method)
+		}
+		paths[route.Path] = item
+	}
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title": "Oasis API Server",
+			"description": "Queries Oasis nodes, their Prometheus " +
+				"endpoints and Node Exporter. Every response is HTTP 200, " +
+				"failures are answered with an object holding an error " +
+				"field. If API keys are configured, requests without a key " +
+				"holding the scopes of route are answered with HTTP 401 " +
+				"or 403.",
+			"version": "1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": b.components,
+			"securitySchemes": map[string]interface{}{
+				"apiKey": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": APIKeyHeader,
+				},
+			},
+		},
+		// API keys are only required if configured
+		"security": []interface{}{
+			map[string]interface{}{"apiKey": []string{}},
+			map[string]interface{}{},
+		},
+	}
+}
+
+// GetOpenAPI returns OpenAPI specification of all routes of the API.
+func GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	lgr.Info.Println("Request at /api/openapi.json responding with " +
+		"OpenAPI specification!")
+	json.NewEncoder(w).Encode(OpenAPISpec(Routes()))
+}
@@ -77,6 +77,9 @@ func GetEntities(w http.ResponseWriter, r *http.Request) {
 	// Retrieve entities at specific block height
 	entities, err := ro.GetEntities(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get entities!"})
 		lgr.Error.Println("Request at /api/registry/entities failed "+
@@ -139,6 +142,9 @@ func GetNodes(w http.ResponseWriter, r *http.Request) {
 	// Retrieve nodes from Registry object at specific height
 	nodes, err := ro.GetNodes(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Nodes!"})
 		lgr.Error.Println(
@@ -201,6 +207,9 @@ func GetRegistryEvents(w http.ResponseWriter, r *http.Request) {
 	// Retrieve the events at specified block height.
 	events, err := ro.GetEvents(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Events!"})
 		lgr.Error.Println(
@@ -271,6 +280,9 @@ func GetRuntimes(w http.ResponseWriter, r *http.Request) {
 	// Retrieving runtimes at specific block height from registry client
 	runtimes, err := ro.GetRuntimes(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get runtimes!"})
 		lgr.Error.Println(
@@ -335,6 +347,9 @@ func GetRegistryStateToGenesis(w http.ResponseWriter, r *http.Request) {
 	// Retrieving genesis state of registry object
 	genesisRegistry, err := ro.StateToGenesis(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Registry Genesis!"})
 		lgr.Error.Println(
@@ -426,6 +441,9 @@ func GetEntity(w http.ResponseWriter, r *http.Request) {
 	// client using above query.
 	registryEntity, err := ro.GetEntity(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Registry Entity!"})
 		lgr.Error.Println("Request at /api/registry/entity failed to"+
@@ -516,6 +534,9 @@ func GetNode(w http.ResponseWriter, r *http.Request) {
 	// Retriveing node object using above query
 	registryNode, err := ro.GetNode(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Registry Node!"})
 		lgr.Error.Println("Request at /api/registry/node failed to "+
@@ -606,6 +627,9 @@ func GetNodeStatus(w http.ResponseWriter, r *http.Request) {
 	// Retriveing a node's status.
 	nodeStatus, err := ro.GetNodeStatus(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Node Status!"})
 		lgr.Error.Println("Request at /api/registry/nodestatus failed to "+
@@ -694,6 +718,9 @@ func GetRuntime(w http.ResponseWriter, r *http.Request) {
 	// Retrieving runtime object using above query
 	registryRuntime, err := ro.GetRuntime(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Registry Runtime!"})
 		lgr.Error.Println("Request at /api/registry/runtime failed "+
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+)
+
+// statusWriter records status of response written through it
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush flushes underlying response writer so that streams keep working
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// RequestMetrics counts requests and measures time taken to answer them,
+// by route, method and status. Internal requests made through router are
+// counted as part of request that made them.
+func RequestMetrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if internal, _ := r.Context().Value(
+			internalContext{}).(bool); internal {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		path := r.URL.Path
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				path = template
+			}
+		}
+
+		start := time.Now()
+		rec := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.status)
+		metrics.Requests.WithLabelValues(path, r.Method, status).Inc()
+		metrics.RequestDuration.WithLabelValues(path, r.Method,
+			status).Observe(time.Since(start).Seconds())
+	})
+}
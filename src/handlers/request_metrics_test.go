@@ -0,0 +1,31 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+)
+
+func Test_RequestMetrics_Counted(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(hdl.RequestMetrics)
+	router.HandleFunc("/api/ping", hdl.Pong)
+
+	counter := metrics.Requests.WithLabelValues("/api/ping", "GET", "200")
+	before := testutil.ToFloat64(counter)
+
+	req, _ := http.NewRequest("GET", "/api/ping", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if got := testutil.ToFloat64(counter) - before; got != 1 {
+		t.Errorf("handler counted unexpected requests: got %v want %v",
+			got, 1)
+	}
+}
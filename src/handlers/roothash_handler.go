@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/grpc"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
+	common_namespace "github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// Number of runtime blocks returned per page if no limit is specified
+const defaultBlocksLimit = 10
+
+// Maximum number of runtime blocks that can be returned in a single page
+const maxBlocksLimit = 100
+
+// loadRootHashClient loads roothash client and returns it
+func loadRootHashClient(socket string) (*grpc.ClientConn, rpc.RootHash) {
+
+	// Attempt to load connection with roothash client
+	connection, rootHashClient, err := rpc.RootHashClient(socket)
+	if err != nil {
+		lgr.Error.Println("Failed to establish connection to roothash"+
+			" client : ", err)
+		return nil, nil
+	}
+	return connection, rootHashClient
+}
+
+// GetRootHashEvents returns roothash events at specified block height.
+func GetRootHashEvents(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, height needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with roothash client
+	connection, rh := loadRootHashClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if rh == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve roothash events at specific block height
+	events, err := rh.GetEvents(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get RootHash Events!"})
+		lgr.Error.Println("Request at /api/roothash/events failed "+
+			"to retrieve RootHash Events : ", err)
+		return
+	}
+
+	// Responding with retrieved roothash events
+	lgr.Info.Println("Request at /api/roothash/events responding with" +
+		" RootHash Events!")
+	json.NewEncoder(w).Encode(responses.RootHashEventsResponse{
+		Events: events})
+}
+
+// GetRuntimeBlocks returns a page of runtime blocks for given runtime
+// namespace, starting at specified round and going back in history.
+func GetRuntimeBlocks(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving round from query request, latest round if not given
+	recvRound := r.URL.Query().Get("round")
+	round, latest, ok := checkRound(recvRound)
+	if !ok {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, round needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Retrieving page size from query request
+	recvLimit := r.URL.Query().Get("limit")
+	limit := checkAmount(recvLimit)
+	if limit < 0 || limit > maxBlocksLimit {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, limit needs to be " +
+				"a string representing an int up to 100!"})
+		return
+	}
+	if limit == 0 {
+		limit = defaultBlocksLimit
+	}
+
+	// Note Make sure that private key that is being sent is coded properly
+	// Example A1X90rT/WK4AOTh/dJsUlOqNDV/nXM6ZU+h+blS9pto= should be
+	// A1X90rT/WK4AOTh/dJsUlOqNDV/nXM6ZU%2Bh%2BblS9pto=
+	var nameSpace common_namespace.Namespace
+	nmspace := r.URL.Query().Get("namespace")
+	if len(nmspace) == 0 {
+
+		// Stop code here no need to establish connection and reply
+		lgr.Warning.Println("Request at /api/roothash/blocks failed" +
+			", namespace can't be empty!")
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "namespace can't be empty!"})
+		return
+	}
+
+	// Unmarshal text into namespace object to be used in query
+	err := nameSpace.UnmarshalText([]byte(nmspace))
+	if err != nil {
+		lgr.Error.Println("Failed to UnmarshalText into Namespace", err)
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to UnmarshalText into Namespace."})
+		return
+	}
+
+	// Attempt to load connection with roothash client
+	connection, rh := loadRootHashClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if rh == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Resolve latest round so that pagination has a fixed starting point
+	if latest {
+		latestBlock, err := rh.GetLatestBlock(context.Background(),
+			nameSpace)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get latest Runtime Block!"})
+			lgr.Error.Println("Request at /api/roothash/blocks failed "+
+				"to retrieve latest Runtime Block : ", err)
+			return
+		}
+		round = latestBlock.Header.Round
+	}
+
+	// Walk back through history until page is full or genesis is reached
+	blocks := []*block.Block{}
+	for i := int64(0); i < limit; i++ {
+		blk, err := rh.GetBlock(context.Background(), nameSpace, round)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Runtime Blocks!"})
+			lgr.Error.Println("Request at /api/roothash/blocks failed "+
+				"to retrieve Runtime Block : ", err)
+			return
+		}
+		blocks = append(blocks, blk)
+
+		if round == 0 {
+			break
+		}
+		round--
+	}
+
+	// Only point to next page if there are older blocks left
+	var nextRound *uint64
+	if last := blocks[len(blocks)-1].Header.Round; last > 0 {
+		next := last - 1
+		nextRound = &next
+	}
+
+	// Responding with retrieved runtime blocks
+	lgr.Info.Println("Request at /api/roothash/blocks responding with" +
+		" Runtime Blocks!")
+	json.NewEncoder(w).Encode(responses.RuntimeBlocksResponse{
+		Blocks: blocks, NextRound: nextRound})
+}
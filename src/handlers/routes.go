@@ -0,0 +1,526 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/SimplyVC/oasis_api_server/src/graphql"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+)
+
+// Parameter describes query parameter of a route
+type Parameter struct {
+	Name        string
+	Type        string
+	Description string
+	Required    bool
+}
+
+// Route describes an endpoint of the API, routes are registered by router
+// and documented in OpenAPI specification from this description
+type Route struct {
+	Path    string
+	Methods []string
+	Group   string
+	Summary string
+
+	// RPCMethod is name of JSON-RPC method answered by route, if any
+	RPCMethod string
+
+	Parameters []Parameter
+
+	// Scopes are API key scopes required to call route, the group of route
+	// if not given
+	Scopes []string
+
+	// Body and Response are values of types of request body and successful
+	// response, used to describe their schema
+	Body     interface{}
+	Response interface{}
+
+	// ContentType of successful responses if they aren't JSON
+	ContentType string
+
+	// Handler answers route, NewHandler is used instead for routes that
+	// call other routes through router
+	Handler    http.HandlerFunc
+	NewHandler func(router http.Handler) http.HandlerFunc
+
+	// Enabled reports whether route is registered, routes are always
+	// registered if not given
+	Enabled func() bool
+
+	// Immutable reports whether response to query never changes, such
+	// responses are cached forever. Responses are cached for TTL if nil.
+	Immutable func(query url.Values) bool
+}
+
+// Parameters shared by most routes
+var (
+	paramName = Parameter{Name: "name", Type: "string", Required: true,
+		Description: "Name of node, or node group, to query"}
+	paramHeight = Parameter{Name: "height", Type: "integer",
+		Description: "Block height to query, latest height if not given"}
+)
+
+// parameter returns required parameter of given type
+func parameter(name string, kind string, description string) Parameter {
+	return Parameter{Name: name, Type: kind, Description: description,
+		Required: true}
+}
+
+// optional returns optional parameter of given type
+func optional(name string, kind string, description string) Parameter {
+	return Parameter{Name: name, Type: kind, Description: description}
+}
+
+// atHeight returns whether query asks for explicit block height, responses
+// of routes reading chain state at height never change
+func atHeight(query url.Values) bool {
+	height, err := strconv.ParseInt(query.Get("height"), 10, 64)
+	return err == nil && height > 0
+}
+
+// Immutability of responses of each route, built from route table on first
+// use
+var (
+	routeImmutableOnce sync.Once
+	routeImmutable     map[string]func(query url.Values) bool
+)
+
+// ImmutableRequest returns whether response to request never changes, as
+// declared by its route in route table
+func ImmutableRequest(r *http.Request) bool {
+	routeImmutableOnce.Do(func() {
+		routeImmutable = map[string]func(query url.Values) bool{}
+		for _, route := range Routes() {
+			if route.Immutable != nil {
+				routeImmutable[route.Path] = route.Immutable
+			}
+		}
+	})
+	immutable, ok := routeImmutable[r.URL.Path]
+	return ok && immutable(r.URL.Query())
+}
+
+// get returns route answered by GET requests, responses of routes taking
+// height are immutable at explicit height
+func get(path string, group string, rpcMethod string, summary string,
+	handler http.HandlerFunc, response interface{},
+	parameters ...Parameter) *Route {
+
+	route := &Route{
+		Path:       path,
+		Methods:    []string{http.MethodGet},
+		Group:      group,
+		Summary:    summary,
+		RPCMethod:  rpcMethod,
+		Parameters: parameters,
+		Response:   response,
+		Handler:    handler,
+	}
+	for _, param := range parameters {
+		if param.Name == paramHeight.Name {
+			route.Immutable = atHeight
+		}
+	}
+	return route
+}
+
+// mutableWith returns route whose responses change over time even at
+// explicit height if flag is set, such as estimates of upcoming epochs
+func mutableWith(flag string, route *Route) *Route {
+	route.Immutable = func(query url.Values) bool {
+		return atHeight(query) && query.Get(flag) != "true"
+	}
+	return route
+}
+
+// Routes returns table of all routes of the API, v1 and v2
+func Routes() []*Route {
+	routes := []*Route{
+		// General routes
+		get("/api/ping", "general", "general.ping",
+			"Checks that API server is running",
+			Pong, responses.SuccessResponse{}),
+		get("/api/getconnectionslist", "general", "general.getConnections",
+			"Lists configured nodes and their sockets",
+			GetConnections, responses.ConnectionsResponse{}),
+		get("/api/consistency", "general", "general.getConsistency",
+			"Compares chain and height of all configured nodes",
+			GetConsistency, responses.ConsistencyReportResponse{},
+			optional("lag", "integer",
+				"Number of blocks a node may lag behind, 10 if not given")),
+		get("/api/cache/stats", "general", "cache.getStats",
+			"Returns response cache statistics",
+			GetCacheStats, responses.CacheStatsResponse{}),
+		get("/api/openapi.json", "general", "",
+			"Returns this OpenAPI specification",
+			GetOpenAPI, nil),
+		{
+			Path:        "/metrics",
+			Methods:     []string{http.MethodGet},
+			Group:       "metrics",
+			Summary:     "Returns Prometheus metrics of the API server",
+			ContentType: "text/plain",
+			Handler:     GetMetrics,
+		},
+		{
+			Path:    "/api/batch",
+			Methods: []string{http.MethodPost},
+			Group:   "general",
+			Summary: "Executes array of GET requests, answered in order " +
+				"and pinned to same latest height",
+			Body:       []BatchRequest{},
+			Response:   responses.BatchResponse{},
+			NewHandler: Batch,
+		},
+		{
+			Path:    "/rpc",
+			Methods: []string{http.MethodPost},
+			Group:   "general",
+			Summary: "Answers JSON-RPC 2.0 requests, or batches of them, " +
+				"through routes of the API",
+			Body:       rpcRequest{},
+			Response:   responses.RPCResponse{},
+			NewHandler: JSONRPC,
+		},
+		{
+			Path:    "/graphql",
+			Methods: []string{http.MethodGet, http.MethodPost},
+			Group:   "general",
+			Summary: "Answers GraphQL queries over consensus, staking, " +
+				"registry and governance data",
+			Scopes: []string{"consensus", "staking", "registry",
+				"governance"},
+			Parameters: []Parameter{paramName, paramHeight,
+				optional("query", "string", "GraphQL query of GET requests"),
+				optional("operationName", "string",
+					"Operation of query to execute"),
+				optional("variables", "string",
+					"JSON object of query variables")},
+			Body:     graphQLRequest{},
+			Response: graphql.Result{},
+			Handler:  GraphQL,
+		},
+
+		// Consensus routes
+		get("/api/consensus/genesis", "consensus",
+			"consensus.getStateToGenesis",
+			"Returns consensus genesis state at height",
+			GetConsensusStateToGenesis, responses.ConsensusGenesisResponse{},
+			paramName, paramHeight),
+		get("/api/consensus/epoch", "consensus", "consensus.getEpoch",
+			"Returns epoch at height",
+			GetEpoch, responses.EpochResponse{}, paramName, paramHeight),
+		get("/api/consensus/epochtiming", "consensus",
+			"consensus.getEpochTiming",
+			"Returns epoch timing and estimates of upcoming transitions",
+			GetEpochTiming, responses.EpochTimingResponse{}, paramName,
+			optional("count", "integer",
+				"Number of upcoming transitions, up to 100")),
+		get("/api/consensus/epochdate", "consensus", "consensus.getEpochDate",
+			"Returns start height and date of epoch",
+			GetEpochDate, responses.EpochTransitionResponse{}, paramName,
+			parameter("epoch", "integer", "Epoch to look up")),
+		get("/api/consensus/block", "consensus", "consensus.getBlock",
+			"Returns block at height",
+			GetBlock, responses.BlockResponse{}, paramName, paramHeight),
+		get("/api/consensus/status", "consensus", "consensus.getStatus",
+			"Returns consensus status of node",
+			GetStatus, responses.StatusResponse{}, paramName),
+		get("/api/consensus/height", "consensus", "consensus.getHeight",
+			"Returns latest height of node",
+			GetHeight, responses.HeightResponse{}, paramName),
+		get("/api/consensus/blockheader", "consensus",
+			"consensus.getBlockHeader",
+			"Returns Tendermint block header at height",
+			GetBlockHeader, responses.BlockHeaderResponse{}, paramName,
+			paramHeight),
+		get("/api/consensus/blocklastcommit", "consensus",
+			"consensus.getBlockLastCommit",
+			"Returns Tendermint last commit of block at height",
+			GetBlockLastCommit, responses.BlockLastCommitResponse{},
+			paramName, paramHeight),
+		get("/api/consensus/pubkeyaddress", "consensus",
+			"consensus.publicKeyToAddress",
+			"Converts consensus public key to Tendermint address",
+			PublicKeyToAddress, responses.TendermintAddress{},
+			parameter("consensus_public_key", "string",
+				"Base64 encoded consensus public key")),
+		get("/api/consensus/pubkeybech32address", "consensus",
+			"consensus.publicKeyToBech32Address",
+			"Converts public key to staking address",
+			PublicKeyToBech32Address, responses.Bech32Address{},
+			parameter("consensus_public_key", "string",
+				"Base64 encoded public key")),
+		get("/api/consensus/base64bech32address", "consensus",
+			"consensus.base64ToBech32Address",
+			"Converts base64 encoded address to staking address",
+			Base64ToBech32Address, responses.Bech32Address{},
+			parameter("address", "string", "Base64 encoded address")),
+		get("/api/consensus/transactions", "consensus",
+			"consensus.getTransactions",
+			"Returns transactions of block at height",
+			GetTransactions, responses.TransactionsResponse{}, paramName,
+			paramHeight),
+		get("/api/consensus/transactionswithresults", "consensus",
+			"consensus.getTransactionsWithResults",
+			"Returns transactions of block at height with their results",
+			GetTransactionsWithResults,
+			responses.TransactionsWithResultsResponse{}, paramName,
+			paramHeight),
+		get("/api/pingnode", "consensus", "general.pingNode",
+			"Checks that node can be reached",
+			PingNode, responses.SuccessResponse{}, paramName),
+
+		// Registry routes
+		get("/api/registry/entities", "registry", "registry.getEntities",
+			"Returns registered entities",
+			GetEntities, responses.EntitiesResponse{}, paramName,
+			paramHeight),
+		get("/api/registry/nodes", "registry", "registry.getNodes",
+			"Returns registered nodes",
+			GetNodes, responses.NodesResponse{}, paramName, paramHeight),
+		get("/api/registry/nodestatus", "registry", "registry.getNodeStatus",
+			"Returns registry status of node",
+			GetNodeStatus, responses.NodeStatusResponse{}, paramName,
+			paramHeight,
+			parameter("nodeID", "string", "Public key of node")),
+		get("/api/registry/events", "registry", "registry.getEvents",
+			"Returns registry events of block at height",
+			GetRegistryEvents, responses.RegistryEventsResponse{},
+			paramName, paramHeight),
+		get("/api/registry/runtimes", "registry", "registry.getRuntimes",
+			"Returns registered runtimes",
+			GetRuntimes, responses.RuntimesResponse{}, paramName,
+			paramHeight, optional("suspended", "boolean",
+				"Whether suspended runtimes are included")),
+		get("/api/registry/genesis", "registry",
+			"registry.getStateToGenesis",
+			"Returns registry genesis state at height",
+			GetRegistryStateToGenesis, responses.RegistryGenesisResponse{},
+			paramName, paramHeight),
+		get("/api/registry/entity", "registry", "registry.getEntity",
+			"Returns registered entity",
+			GetEntity, responses.RegistryEntityResponse{}, paramName,
+			paramHeight,
+			parameter("entity", "string", "Public key of entity")),
+		get("/api/registry/node", "registry", "registry.getNode",
+			"Returns registered node",
+			GetNode, responses.RegistryNodeResponse{}, paramName,
+			paramHeight,
+			parameter("nodeID", "string", "Public key of node")),
+		get("/api/registry/runtime", "registry", "registry.getRuntime",
+			"Returns registered runtime",
+			GetRuntime, responses.RuntimeResponse{}, paramName,
+			paramHeight,
+			parameter("namespace", "string", "Namespace of runtime")),
+
+		// Staking routes
+		get("/api/staking/totalsupply", "staking", "staking.getTotalSupply",
+			"Returns total supply of tokens",
+			GetTotalSupply, responses.QuantityResponse{}, paramName,
+			paramHeight),
+		get("/api/staking/commonpool", "staking", "staking.getCommonPool",
+			"Returns balance of common pool",
+			GetCommonPool, responses.QuantityResponse{}, paramName,
+			paramHeight),
+		get("/api/staking/genesis", "staking", "staking.getStateToGenesis",
+			"Returns staking genesis state at height",
+			GetStakingStateToGenesis, responses.StakingGenesisResponse{},
+			paramName, paramHeight),
+		get("/api/staking/threshold", "staking", "staking.getThreshold",
+			"Returns staking threshold of kind",
+			GetThreshold, responses.QuantityResponse{}, paramName,
+			paramHeight,
+			parameter("kind", "integer", "Kind of threshold")),
+		get("/api/staking/accounts", "staking", "staking.getAccounts",
+			"Returns addresses of all accounts",
+			GetAccounts, responses.AllAccountsResponse{}, paramName,
+			paramHeight),
+		get("/api/staking/accountinfo", "staking", "staking.getAccountInfo",
+			"Returns account",
+			GetAccountInfo, responses.AccountResponse{}, paramName,
+			paramHeight,
+			parameter("ownerKey", "string", "Address of account")),
+		get("/api/staking/delegations", "staking", "staking.getDelegations",
+			"Returns delegations of account",
+			GetDelegationsFor, responses.DelegationsResponse{}, paramName,
+			paramHeight,
+			parameter("ownerKey", "string", "Address of account")),
+		get("/api/staking/debondingdelegations", "staking",
+			"staking.getDebondingDelegations",
+			"Returns debonding delegations of account",
+			GetDebondingDelegationsFor,
+			responses.DebondingDelegationsResponse{}, paramName,
+			paramHeight,
+			parameter("ownerKey", "string", "Address of account")),
+		get("/api/staking/events", "staking", "staking.getEvents",
+			"Returns staking events of block at height",
+			GetEvents, responses.StakingEvents{}, paramName, paramHeight),
+
+		// Node controller routes
+		get("/api/nodecontroller/synced", "nodecontroller",
+			"nodecontroller.getIsSynced",
+			"Returns whether node is synced",
+			GetIsSynced, responses.IsSyncedResponse{}, paramName),
+		get("/api/nodecontroller/status", "nodecontroller",
+			"nodecontroller.getStatus",
+			"Returns full status of node",
+			GetControlStatus, responses.ControlStatusResponse{}, paramName),
+		get("/api/nodecontroller/waitready", "nodecontroller", "",
+			"Streams sync progress until node is ready",
+			WaitReady, responses.WaitProgressResponse{}, paramName,
+			optional("timeout", "integer",
+				"Seconds to wait, 600 if not given"),
+			optional("reference", "string",
+				"Node whose height node is compared to")),
+
+		// Scheduler routes
+		get("/api/scheduler/validators", "scheduler",
+			"scheduler.getValidators",
+			"Returns validators at height",
+			GetValidators, responses.ValidatorsResponse{}, paramName,
+			paramHeight),
+		get("/api/scheduler/committees", "scheduler",
+			"scheduler.getCommittees",
+			"Returns committees of runtime at height",
+			GetCommittees, responses.CommitteesResponse{}, paramName,
+			paramHeight,
+			parameter("namespace", "string", "Namespace of runtime")),
+		get("/api/scheduler/genesis", "scheduler",
+			"scheduler.getStateToGenesis",
+			"Returns scheduler genesis state at height",
+			GetSchedulerStateToGenesis, responses.SchedulerGenesisState{},
+			paramName, paramHeight),
+		mutableWith("next", get("/api/scheduler/nodecommittees",
+			"scheduler", "scheduler.getNodeCommittees",
+			"Returns committee memberships of node or of nodes of entity",
+			GetNodeCommittees, responses.NodeCommitteesResponse{},
+			paramName, paramHeight,
+			optional("nodeID", "string", "Public key of node"),
+			optional("entityID", "string", "Public key of entity"),
+			optional("next", "boolean",
+				"Whether next epoch is also estimated"))),
+		get("/api/scheduler/validatoroutlook", "scheduler",
+			"scheduler.getValidatorOutlook",
+			"Returns outlook of next validator election",
+			GetValidatorOutlook, responses.ValidatorOutlookResponse{},
+			paramName, paramHeight),
+
+		// Prometheus and Node Exporter routes
+		get("/api/prometheus/gauge", "prometheus", "prometheus.queryGauge",
+			"Returns value of gauge exposed by node",
+			PrometheusQueryGauge, responses.SuccessResponse{}, paramName,
+			parameter("gauge", "string", "Name of gauge")),
+		get("/api/prometheus/counter", "prometheus",
+			"prometheus.queryCounter",
+			"Returns value of counter exposed by node",
+			PrometheusQueryCounter, responses.SuccessResponse{}, paramName,
+			parameter("counter", "string", "Name of counter")),
+		get("/api/exporter/gauge", "exporter", "exporter.queryGauge",
+			"Returns value of gauge exposed by Node Exporter",
+			NodeExporterQueryGauge, responses.SuccessResponse{},
+			parameter("gauge", "string", "Name of gauge")),
+		get("/api/exporter/counter", "exporter", "exporter.queryCounter",
+			"Returns value of counter exposed by Node Exporter",
+			NodeExporterQueryCounter, responses.SuccessResponse{},
+			parameter("counter", "string", "Name of counter")),
+
+		// Sentry routes
+		get("/api/sentry/addresses", "sentry", "sentry.getAddresses",
+			"Returns addresses of nodes connected to sentry",
+			GetSentryAddresses, responses.SentryResponse{}, paramName),
+
+		// Governance routes
+		get("/api/governance/activeproposals", "governance",
+			"governance.getActiveProposals",
+			"Returns proposals that have not closed yet",
+			GetActiveProposals, responses.ProposalsResponse{}, paramName,
+			paramHeight),
+		get("/api/governance/proposals", "governance",
+			"governance.getProposals",
+			"Returns all proposals",
+			GetProposals, responses.ProposalsResponse{}, paramName,
+			paramHeight),
+		get("/api/governance/proposal", "governance",
+			"governance.getProposal",
+			"Returns proposal",
+			GetProposal, responses.ProposalResponse{}, paramName,
+			paramHeight,
+			parameter("id", "integer", "ID of proposal")),
+		get("/api/governance/votes", "governance", "governance.getVotes",
+			"Returns votes of proposal",
+			GetVotes, responses.VotesResponse{}, paramName, paramHeight,
+			parameter("id", "integer", "ID of proposal")),
+		get("/api/governance/tally", "governance", "governance.getTally",
+			"Returns tally of proposal weighted by voter stake",
+			GetProposalTally, responses.ProposalTallyResponse{}, paramName,
+			parameter("id", "integer", "ID of proposal")),
+		get("/api/governance/pendingupgrades", "governance",
+			"governance.getPendingUpgrades",
+			"Returns pending upgrades and readiness of configured nodes",
+			GetPendingUpgrades, responses.PendingUpgradesResponse{},
+			paramName),
+		get("/api/governance/parameters", "governance",
+			"governance.getParameters",
+			"Returns governance consensus parameters",
+			GetGovernanceParameters,
+			responses.GovernanceParametersResponse{}, paramName,
+			paramHeight),
+		get("/api/governance/timeline", "governance",
+			"governance.getTimeline",
+			"Returns timeline of proposal with estimated dates",
+			GetProposalTimeline, responses.ProposalTimelineResponse{},
+			paramName,
+			parameter("id", "integer", "ID of proposal")),
+		{
+			Path:    "/api/governance/castvote",
+			Methods: []string{http.MethodPost},
+			Group:   "governance",
+			Summary: "Casts vote on proposal signed by configured signer",
+			Scopes:  []string{"governance", ScopeWrite},
+			Parameters: []Parameter{paramName,
+				parameter("signer", "string", "Name of configured signer"),
+				parameter("id", "integer", "ID of proposal"),
+				parameter("vote", "string", "One of yes, no or abstain"),
+				optional("dryrun", "boolean",
+					"Whether unsigned transaction is returned instead "+
+						"of submitted")},
+			Response: responses.CastVoteResponse{},
+			Handler:  CastVote,
+			Enabled:  voteCastingEnabled,
+		},
+
+		// Beacon routes
+		get("/api/beacon/state", "beacon", "beacon.getState",
+			"Returns beacon state and parameters",
+			GetBeaconState, responses.BeaconStateResponse{}, paramName,
+			paramHeight),
+		get("/api/beacon/watchepochs", "beacon", "",
+			"Streams epochs as they change",
+			WatchEpochs, responses.EpochResponse{}, paramName,
+			optional("timeout", "integer",
+				"Seconds to stream for, until disconnect if not given")),
+
+		// RootHash routes
+		get("/api/roothash/events", "roothash", "roothash.getEvents",
+			"Returns roothash events of block at height",
+			GetRootHashEvents, responses.RootHashEventsResponse{},
+			paramName, paramHeight),
+		get("/api/roothash/blocks", "roothash", "roothash.getRuntimeBlocks",
+			"Returns page of runtime blocks",
+			GetRuntimeBlocks, responses.RuntimeBlocksResponse{}, paramName,
+			parameter("namespace", "string", "Namespace of runtime"),
+			optional("round", "integer",
+				"Round to page back from, latest if not given"),
+			optional("limit", "integer",
+				"Number of blocks returned, up to 100")),
+	}
+
+	// Every v1 route is also served under /api/v2
+	return append(routes, v2Routes(routes)...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"sort"
 
 	"google.golang.org/grpc"
 
@@ -11,7 +12,11 @@ import (
 	"github.com/SimplyVC/oasis_api_server/src/responses"
 	"github.com/SimplyVC/oasis_api_server/src/rpc"
 	common_namespace "github.com/oasisprotocol/oasis-core/go/common"
+	common_signature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	common_node "github.com/oasisprotocol/oasis-core/go/common/node"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 // loadSchedulerClient loads scheduler client and returns it
@@ -76,6 +81,9 @@ func GetValidators(w http.ResponseWriter, r *http.Request) {
 	// Retrieve validators at given block height
 	validators, err := sc.GetValidators(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Validators!"})
 		lgr.Error.Println("Request at /api/scheduler/validators "+
@@ -166,6 +174,9 @@ func GetCommittees(w http.ResponseWriter, r *http.Request) {
 	// Retrieving Committees using query above
 	committees, err := sc.GetCommittees(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Committees!"})
 		lgr.Error.Println("Request at /api/scheduler/committees "+
@@ -229,6 +240,9 @@ func GetSchedulerStateToGenesis(w http.ResponseWriter, r *http.Request) {
 	// Retrieve genesis state of scheduler at specific block height
 	gensis, err := sc.StateToGenesis(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Scheduler Genesis State!"})
 		lgr.Error.Println("Request at /api/scheduler/genesis failed "+
@@ -242,3 +256,503 @@ func GetSchedulerStateToGenesis(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(responses.SchedulerGenesisState{
 		SchedulerGenesisState: gensis})
 }
+
+// committeeMemberships returns committee memberships of given nodes across
+// all given runtimes at specified block height.
+func committeeMemberships(sc scheduler.Backend, runtimes []*registry.Runtime,
+	nodeIDs map[common_signature.PublicKey]bool,
+	height int64) ([]*responses.CommitteeMembership, error) {
+
+	memberships := []*responses.CommitteeMembership{}
+	for _, runtime := range runtimes {
+
+		// Retrieve all committees of runtime and look for requested nodes
+		query := scheduler.GetCommitteesRequest{Height: height,
+			RuntimeID: runtime.ID}
+		committees, err := sc.GetCommittees(context.Background(), &query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, committee := range committees {
+			for _, member := range committee.Members {
+				if !nodeIDs[member.PublicKey] {
+					continue
+				}
+				memberships = append(memberships,
+					&responses.CommitteeMembership{
+						NodeID:    member.PublicKey,
+						RuntimeID: committee.RuntimeID,
+						Kind:      committee.Kind,
+						Role:      member.Role,
+						ValidFor:  committee.ValidFor,
+					})
+			}
+		}
+	}
+	return memberships, nil
+}
+
+// GetNodeCommittees returns every committee across all registered runtimes
+// that a node, or any node of an entity, is a member of. Committees of the
+// upcoming epoch are included on request once they have been elected.
+func GetNodeCommittees(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, height needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Note Make sure that public key that is being sent is coded properly
+	// Example A1X90rT/WK4AOTh/dJsUlOqNDV/nXM6ZU+h+blS9pto= should be
+	// A1X90rT/WK4AOTh/dJsUlOqNDV/nXM6ZU%2Bh%2BblS9pto=
+	var pubKey common_signature.PublicKey
+	nodeID := r.URL.Query().Get("nodeID")
+	entityID := r.URL.Query().Get("entityID")
+	if len(nodeID) == 0 && len(entityID) == 0 {
+
+		// Stop code here no need to establish connection and reply
+		lgr.Warning.Println("Request at /api/scheduler/nodecommittees " +
+			"failed, nodeID or entityID is required!")
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "nodeID or entityID can't be empty!"})
+		return
+	}
+
+	// Node ID takes precedence over entity ID if both are given
+	recvKey := nodeID
+	if len(recvKey) == 0 {
+		recvKey = entityID
+	}
+
+	// Unmarshal received text into public key object
+	err := pubKey.UnmarshalText([]byte(recvKey))
+	if err != nil {
+		lgr.Error.Println("Failed to UnmarshalText into Public Key", err)
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to UnmarshalText into Public Key."})
+		return
+	}
+
+	// Whether committees of upcoming epoch should be included
+	includeNext := r.URL.Query().Get("next") == "true"
+
+	// Attempt to load connection with scheduler client
+	connection, sc := loadSchedulerClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if sc == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with registry client
+	registryConnection, ro := loadRegistryClient(socket)
+
+	// Close connection once code underneath executes
+	defer registryConnection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with beacon client
+	beaconConnection, be := loadBeaconClient(socket)
+
+	// Close connection once code underneath executes
+	defer beaconConnection.Close()
+
+	// If null object was retrieved send response
+	if be == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Nodes whose committee memberships are looked up
+	nodeIDs := map[common_signature.PublicKey]bool{}
+	if len(nodeID) != 0 {
+		nodeIDs[pubKey] = true
+	} else {
+
+		// Retrieve all registered nodes and keep ones owned by entity
+		nodes, err := ro.GetNodes(context.Background(), height)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Nodes!"})
+			lgr.Error.Println("Request at /api/scheduler/nodecommittees "+
+				"failed to retrieve Nodes : ", err)
+			return
+		}
+		for _, node := range nodes {
+			if node.EntityID.Equal(pubKey) {
+				nodeIDs[node.ID] = true
+			}
+		}
+	}
+
+	// Retrieve all registered runtimes at specific block height
+	runtimeQuery := registry.GetRuntimesQuery{Height: height,
+		IncludeSuspended: false}
+	runtimes, err := ro.GetRuntimes(context.Background(), &runtimeQuery)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Runtimes!"})
+		lgr.Error.Println("Request at /api/scheduler/nodecommittees "+
+			"failed to retrieve Runtimes : ", err)
+		return
+	}
+
+	// Retrieve epoch of specific block height
+	epoch, err := be.GetEpoch(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to retrieve Epoch of Block!"})
+		lgr.Error.Println("Request at /api/scheduler/nodecommittees "+
+			"failed to retrieve Epoch : ", err)
+		return
+	}
+
+	// Retrieve committee memberships of current epoch
+	current, err := committeeMemberships(sc, runtimes, nodeIDs, height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Committees!"})
+		lgr.Error.Println("Request at /api/scheduler/nodecommittees "+
+			"failed to retrieve Committees : ", err)
+		return
+	}
+
+	nodeCommittees := &responses.NodeCommittees{
+		Current: &responses.EpochCommittees{
+			Epoch:       epoch,
+			Elected:     true,
+			Memberships: current,
+		},
+	}
+
+	if includeNext {
+		nodeCommittees.Next = &responses.EpochCommittees{
+			Epoch:       epoch + 1,
+			Memberships: []*responses.CommitteeMembership{},
+		}
+
+		// Committees of upcoming epoch are only elected once the first
+		// block of that epoch exists
+		nextHeight, err := be.GetEpochBlock(context.Background(), epoch+1)
+		if err == nil && nextHeight > 0 {
+			next, err := committeeMemberships(sc, runtimes, nodeIDs,
+				nextHeight)
+			if err != nil {
+				if callLimited(w, err) {
+					return
+				}
+				json.NewEncoder(w).Encode(responses.ErrorResponse{
+					Error: "Failed to get Committees!"})
+				lgr.Error.Println("Request at /api/scheduler/"+
+					"nodecommittees failed to retrieve Committees : ", err)
+				return
+			}
+			nodeCommittees.Next.Elected = true
+			nodeCommittees.Next.Height = nextHeight
+			nodeCommittees.Next.Memberships = next
+		} else {
+
+			// Report height at which election is scheduled if known
+			future, err := be.GetFutureEpoch(context.Background(), height)
+			if err == nil && future != nil && future.Epoch == epoch+1 {
+				nodeCommittees.Next.Height = future.Height
+			}
+		}
+	}
+
+	// Responding with committee memberships retrieved above
+	lgr.Info.Println("Request at /api/scheduler/nodecommittees responding " +
+		"with Committee Memberships!")
+	json.NewEncoder(w).Encode(responses.NodeCommitteesResponse{
+		NodeCommittees: nodeCommittees})
+}
+
+// rankValidatorCandidates sorts candidates by descending escrow balance and
+// fills the validator set the same way the scheduler does, giving each
+// entity up to the per-entity limit of slots until the set is full. Margin
+// of elected entities is their lead over the best excluded entity, margin of
+// excluded entities is how much they are short of the cutoff.
+func rankValidatorCandidates(candidates []*responses.ValidatorCandidate,
+	params *scheduler.ConsensusParameters) *responses.ValidatorOutlook {
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].EscrowBalance.Cmp(
+			&candidates[j].EscrowBalance) > 0
+	})
+
+	outlook := &responses.ValidatorOutlook{
+		MinValidators:          params.MinValidators,
+		MaxValidators:          params.MaxValidators,
+		MaxValidatorsPerEntity: params.MaxValidatorsPerEntity,
+		Candidates:             candidates,
+	}
+
+	// Fill validator set in order of stake
+	var cutoff, next *responses.ValidatorCandidate
+	for i, candidate := range candidates {
+		candidate.Rank = i + 1
+		if outlook.ProjectedValidators >= params.MaxValidators {
+			if next == nil {
+				next = candidate
+			}
+			continue
+		}
+
+		slots := len(candidate.ValidatorNodes)
+		if slots > params.MaxValidatorsPerEntity {
+			slots = params.MaxValidatorsPerEntity
+		}
+		if free := params.MaxValidators - outlook.ProjectedValidators; slots > free {
+			slots = free
+		}
+		candidate.Slots = slots
+		candidate.ProjectedElected = slots > 0
+		outlook.ProjectedValidators += slots
+		cutoff = candidate
+	}
+
+	if cutoff != nil {
+		outlook.CutoffBalance = cutoff.EscrowBalance
+	}
+
+	// Compute margins against cutoff and best excluded entity
+	for _, candidate := range candidates {
+		balance := candidate.EscrowBalance.ToBigInt()
+		switch {
+		case candidate.ProjectedElected && next != nil:
+			candidate.Margin = balance.Sub(balance,
+				next.EscrowBalance.ToBigInt()).String()
+		case candidate.ProjectedElected:
+			candidate.Margin = balance.String()
+		default:
+			candidate.Margin = balance.Sub(balance,
+				outlook.CutoffBalance.ToBigInt()).String()
+		}
+	}
+	return outlook
+}
+
+// GetValidatorOutlook ranks all entities running validator nodes by escrow
+// balance against validator set limits of scheduler, showing how close each
+// entity is to the validator set cutoff.
+func GetValidatorOutlook(w http.ResponseWriter, r *http.Request) {
+
+	// Add header so that received knows they're receiving JSON
+	w.Header().Add("Content-Type", "application/json")
+
+	// Retrieving name of node from query request
+	nodeName := r.URL.Query().Get("name")
+	confirmation, socket := checkNodeName(nodeName)
+	if !confirmation {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Node name requested doesn't exist"})
+		return
+	}
+
+	// Retrieving height from query request
+	recvHeight := r.URL.Query().Get("height")
+	height := checkHeight(recvHeight)
+	if height == -1 {
+
+		// Stop code here no need to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Unexpected value found, height needs to be " +
+				"a string representing an int!"})
+		return
+	}
+
+	// Attempt to load connection with scheduler client
+	connection, sc := loadSchedulerClient(socket)
+
+	// Close connection once code underneath executes
+	defer connection.Close()
+
+	// If null object was retrieved send response
+	if sc == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with registry client
+	registryConnection, ro := loadRegistryClient(socket)
+
+	// Close connection once code underneath executes
+	defer registryConnection.Close()
+
+	// If null object was retrieved send response
+	if ro == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Attempt to load connection with staking client
+	stakingConnection, so := loadStakingClient(socket)
+
+	// Close connection once code underneath executes
+	defer stakingConnection.Close()
+
+	// If null object was retrieved send response
+	if so == nil {
+
+		// Stop code here faild to establish connection and reply
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to establish connection using socket: " +
+				socket})
+		return
+	}
+
+	// Retrieve scheduler parameters containing validator set limits
+	params, err := sc.ConsensusParameters(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Scheduler Consensus Parameters!"})
+		lgr.Error.Println("Request at /api/scheduler/validatoroutlook "+
+			"failed to retrieve Scheduler Consensus Parameters : ", err)
+		return
+	}
+
+	// Retrieve current validators to mark entities already in the set
+	validators, err := sc.GetValidators(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Validators!"})
+		lgr.Error.Println("Request at /api/scheduler/validatoroutlook "+
+			"failed to retrieve Validators : ", err)
+		return
+	}
+	activeValidators := map[common_signature.PublicKey]bool{}
+	for _, validator := range validators {
+		activeValidators[validator.ID] = true
+	}
+
+	// Retrieve nodes at specific block height
+	nodes, err := ro.GetNodes(context.Background(), height)
+	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
+		json.NewEncoder(w).Encode(responses.ErrorResponse{
+			Error: "Failed to get Nodes!"})
+		lgr.Error.Println("Request at /api/scheduler/validatoroutlook "+
+			"failed to retrieve Nodes : ", err)
+		return
+	}
+
+	// Group validator nodes by the entity that owns them
+	candidates := []*responses.ValidatorCandidate{}
+	byEntity := map[common_signature.PublicKey]*responses.ValidatorCandidate{}
+	for _, node := range nodes {
+		if !node.HasRoles(common_node.RoleValidator) {
+			continue
+		}
+		candidate, ok := byEntity[node.EntityID]
+		if !ok {
+			candidate = &responses.ValidatorCandidate{
+				EntityID:       node.EntityID,
+				Address:        staking.NewAddress(node.EntityID),
+				ValidatorNodes: []common_signature.PublicKey{},
+			}
+			byEntity[node.EntityID] = candidate
+			candidates = append(candidates, candidate)
+		}
+		candidate.ValidatorNodes = append(candidate.ValidatorNodes, node.ID)
+		if activeValidators[node.ID] {
+			candidate.InValidatorSet = true
+		}
+	}
+
+	// Retrieve escrow balance of every candidate entity
+	for _, candidate := range candidates {
+		query := staking.OwnerQuery{Height: height,
+			Owner: candidate.Address}
+		account, err := so.Account(context.Background(), &query)
+		if err != nil {
+			if callLimited(w, err) {
+				return
+			}
+			json.NewEncoder(w).Encode(responses.ErrorResponse{
+				Error: "Failed to get Account!"})
+			lgr.Error.Println("Request at /api/scheduler/"+
+				"validatoroutlook failed to retrieve Account : ", err)
+			return
+		}
+		candidate.EscrowBalance = account.Escrow.Active.Balance
+	}
+
+	// Responding with candidates ranked against validator set cutoff
+	lgr.Info.Println("Request at /api/scheduler/validatoroutlook " +
+		"responding with Validator Outlook!")
+	json.NewEncoder(w).Encode(responses.ValidatorOutlookResponse{
+		Outlook: rankValidatorCandidates(candidates, params)})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+// testCandidate returns validator candidate of entity identified by id,
+// running given number of validator nodes
+func testCandidate(id byte, balance uint64,
+	nodes int) *responses.ValidatorCandidate {
+
+	candidate := &responses.ValidatorCandidate{
+		EscrowBalance: *quantity.NewFromUint64(balance),
+	}
+	candidate.EntityID[0] = id
+	for i := 0; i < nodes; i++ {
+		var node signature.PublicKey
+		node[0], node[1] = id, byte(i)
+		candidate.ValidatorNodes = append(candidate.ValidatorNodes, node)
+	}
+	return candidate
+}
+
+func Test_RankValidatorCandidates(t *testing.T) {
+	type ranked struct {
+		id      byte
+		slots   int
+		elected bool
+		margin  string
+	}
+
+	tests := []struct {
+		name       string
+		params     scheduler.ConsensusParameters
+		candidates []*responses.ValidatorCandidate
+		projected  int
+		cutoff     uint64
+		want       []ranked
+	}{
+		{
+			name: "PerEntityLimitAndTie",
+			params: scheduler.ConsensusParameters{
+				MinValidators: 1, MaxValidators: 4,
+				MaxValidatorsPerEntity: 2,
+			},
+			candidates: []*responses.ValidatorCandidate{
+				testCandidate(5, 100, 1), testCandidate(3, 200, 2),
+				testCandidate(1, 500, 3), testCandidate(4, 200, 1),
+				testCandidate(2, 300, 1),
+			},
+			projected: 4,
+			cutoff:    200,
+			want: []ranked{
+				{1, 2, true, "300"},
+				{2, 1, true, "100"},
+				{3, 1, true, "0"},
+				{4, 0, false, "0"},
+				{5, 0, false, "-100"},
+			},
+		},
+		{
+			name: "SetNotFull",
+			params: scheduler.ConsensusParameters{
+				MinValidators: 1, MaxValidators: 10,
+				MaxValidatorsPerEntity: 1,
+			},
+			candidates: []*responses.ValidatorCandidate{
+				testCandidate(2, 50, 2), testCandidate(1, 80, 1),
+			},
+			projected: 2,
+			cutoff:    50,
+			want: []ranked{
+				{1, 1, true, "80"},
+				{2, 1, true, "50"},
+			},
+		},
+		{
+			name: "FirstExcludedAtLimit",
+			params: scheduler.ConsensusParameters{
+				MinValidators: 1, MaxValidators: 2,
+				MaxValidatorsPerEntity: 2,
+			},
+			candidates: []*responses.ValidatorCandidate{
+				testCandidate(1, 900, 2), testCandidate(2, 700, 1),
+				testCandidate(3, 600, 1),
+			},
+			projected: 2,
+			cutoff:    900,
+			want: []ranked{
+				{1, 2, true, "200"},
+				{2, 0, false, "-200"},
+				{3, 0, false, "-300"},
+			},
+		},
+	}
+	for _, test := range tests {
+		params := test.params
+		outlook := rankValidatorCandidates(test.candidates, &params)
+
+		if outlook.ProjectedValidators != test.projected {
+			t.Errorf("%s: projected %d validators want %d", test.name,
+				outlook.ProjectedValidators, test.projected)
+		}
+		cutoff := quantity.NewFromUint64(test.cutoff)
+		if outlook.CutoffBalance.Cmp(cutoff) != 0 {
+			t.Errorf("%s: cutoff balance %s want %s", test.name,
+				outlook.CutoffBalance.String(), cutoff.String())
+		}
+		if len(outlook.Candidates) != len(test.want) {
+			t.Fatalf("%s: ranked %d candidates want %d", test.name,
+				len(outlook.Candidates), len(test.want))
+		}
+		for i, want := range test.want {
+			got := outlook.Candidates[i]
+			if got.Rank != i+1 || got.EntityID[0] != want.id ||
+				got.Slots != want.slots ||
+				got.ProjectedElected != want.elected ||
+				got.Margin != want.margin {
+				t.Errorf("%s: rank %d is entity %d with %d slots, "+
+					"elected %v, margin %s want entity %d with %d slots, "+
+					"elected %v, margin %s", test.name, got.Rank,
+					got.EntityID[0], got.Slots, got.ProjectedElected,
+					got.Margin, want.id, want.slots, want.elected,
+					want.margin)
+			}
+		}
+	}
+}
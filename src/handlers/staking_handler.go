@@ -71,6 +71,9 @@ func GetTotalSupply(w http.ResponseWriter, r *http.Request) {
 	// Using Oasis API to return total supply of tokens at specific block height
 	totalSupply, err := so.TotalSupply(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get TotalSupply!"})
 		lgr.Error.Println(
@@ -130,6 +133,9 @@ func GetCommonPool(w http.ResponseWriter, r *http.Request) {
 	// Return common pool at specific block height
 	commonPool, err := so.CommonPool(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Common Pool!"})
 
@@ -190,6 +196,9 @@ func GetStakingStateToGenesis(w http.ResponseWriter, r *http.Request) {
 	// Returning state to genesis at specific height
 	genesisStaking, err := so.StateToGenesis(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Staking Genesis State!"})
 		lgr.Error.Println(
@@ -265,6 +274,9 @@ func GetThreshold(w http.ResponseWriter, r *http.Request) {
 	// Return threshold from staking client using created query
 	threshold, err := so.Threshold(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Threshold!"})
 		lgr.Error.Println(
@@ -325,6 +337,9 @@ func GetAccounts(w http.ResponseWriter, r *http.Request) {
 	// Return accounts from staking client
 	accounts, err := so.Addresses(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Accounts!"})
 		lgr.Error.Println(
@@ -423,6 +438,9 @@ func GetAccountInfo(w http.ResponseWriter, r *http.Request) {
 	// Retrieve account information using created query
 	account, err := so.Account(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Account!"})
 		lgr.Error.Println(
@@ -520,6 +538,9 @@ func GetDelegationsFor(w http.ResponseWriter, r *http.Request) {
 	// Return delegations for given account query
 	delegationsFor, err := so.DelegationsFor(context.Background(), &query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Delegations!"})
 
@@ -620,6 +641,9 @@ func GetDebondingDelegationsFor(w http.ResponseWriter, r *http.Request) {
 	debondingDelegationsFor, err := so.DebondingDelegationsFor(context.Background(),
 		&query)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Debonding Delegations!"})
 		lgr.Error.Println(
@@ -682,6 +706,9 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	// Return accounts from staking client
 	events, err := so.GetEvents(context.Background(), height)
 	if err != nil {
+		if callLimited(w, err) {
+			return
+		}
 		json.NewEncoder(w).Encode(responses.ErrorResponse{
 			Error: "Failed to get Events!"})
 		lgr.Error.Println(
@@ -330,14 +330,14 @@ func Test_GetThreshold_Height3(t *testing.T) {
 	}
 }
 
-func Test_GetAddresses_BadNode(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/addresses", nil)
+func Test_GetAccounts_BadNode(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accounts", nil)
 	q := req.URL.Query()
 	q.Add("name", "Unicorn")
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAddresses)
+	handler := http.HandlerFunc(hdl.GetAccounts)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -352,8 +352,8 @@ func Test_GetAddresses_BadNode(t *testing.T) {
 	}
 }
 
-func Test_GetAddresses_InvalidHeight(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/addresses", nil)
+func Test_GetAccounts_InvalidHeight(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accounts", nil)
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "Unicorn")
@@ -361,7 +361,7 @@ func Test_GetAddresses_InvalidHeight(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAddresses)
+	handler := http.HandlerFunc(hdl.GetAccounts)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -376,8 +376,8 @@ func Test_GetAddresses_InvalidHeight(t *testing.T) {
 	}
 }
 
-func Test_GetAddresses_Height3(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/addresses", nil)
+func Test_GetAccounts_Height3(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accounts", nil)
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "3")
@@ -385,7 +385,7 @@ func Test_GetAddresses_Height3(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAddresses)
+	handler := http.HandlerFunc(hdl.GetAccounts)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -394,11 +394,11 @@ func Test_GetAddresses_Height3(t *testing.T) {
 
 	expected := "result"
 
-	AllAddresses := &responses.AllAddressesResponse{
-		AllAddresses: []staking_api.Address{},
+	AllAccounts := &responses.AllAccountsResponse{
+		AllAccounts: []staking_api.Address{},
 	}
 
-	err := json.Unmarshal([]byte(rr.Body.String()), AllAddresses)
+	err := json.Unmarshal([]byte(rr.Body.String()), AllAccounts)
 	if err != nil {
 		t.Errorf("Failed to unmarshall data")
 	}
@@ -409,14 +409,14 @@ func Test_GetAddresses_Height3(t *testing.T) {
 	}
 }
 
-func Test_GetAccount_BadNode(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/account", nil)
+func Test_GetAccountInfo_BadNode(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accountinfo", nil)
 	q := req.URL.Query()
 	q.Add("name", "Unicorn")
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAccount)
+	handler := http.HandlerFunc(hdl.GetAccountInfo)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -431,8 +431,8 @@ func Test_GetAccount_BadNode(t *testing.T) {
 	}
 }
 
-func Test_GetAccount_InvalidHeight(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/account", nil)
+func Test_GetAccountInfo_InvalidHeight(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accountinfo", nil)
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "Unicorn")
@@ -440,7 +440,7 @@ func Test_GetAccount_InvalidHeight(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAccount)
+	handler := http.HandlerFunc(hdl.GetAccountInfo)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -455,17 +455,17 @@ func Test_GetAccount_InvalidHeight(t *testing.T) {
 	}
 }
 
-func Test_GetAccount_Height3(t *testing.T) {
-	req, _ := http.NewRequest("GET", "/api/staking/account", nil)
+func Test_GetAccountInfo_Height3(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/staking/accountinfo", nil)
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "3")
-	q.Add("address", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
+	q.Add("ownerKey", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
 
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetAccount)
+	handler := http.HandlerFunc(hdl.GetAccountInfo)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -475,7 +475,7 @@ func Test_GetAccount_Height3(t *testing.T) {
 	expected := "result"
 
 	account := &responses.AccountResponse{
-		Account: &staking_api.Account{},
+		AccountInfo: &staking_api.Account{},
 	}
 
 	err := json.Unmarshal([]byte(rr.Body.String()), account)
@@ -496,7 +496,7 @@ func Test_GetDelegations_BadNode(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDelegations)
+	handler := http.HandlerFunc(hdl.GetDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -520,7 +520,7 @@ func Test_GetDelegations_InvalidHeight(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDelegations)
+	handler := http.HandlerFunc(hdl.GetDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -540,12 +540,12 @@ func Test_GetDelegations_Height3(t *testing.T) {
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "3")
-	q.Add("address", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
+	q.Add("ownerKey", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
 
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDelegations)
+	handler := http.HandlerFunc(hdl.GetDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -576,7 +576,7 @@ func Test_GetDebondingDelegations_BadNode(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDebondingDelegations)
+	handler := http.HandlerFunc(hdl.GetDebondingDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -600,7 +600,7 @@ func Test_GetDebondingDelegations_InvalidHeight(t *testing.T) {
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDebondingDelegations)
+	handler := http.HandlerFunc(hdl.GetDebondingDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -620,12 +620,12 @@ func Test_GetDebondingDelegations_Height3(t *testing.T) {
 	q := req.URL.Query()
 	q.Add("name", "Oasis_Local")
 	q.Add("height", "3")
-	q.Add("address", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
+	q.Add("ownerKey", "oasis1qqqf342r78nz05dq2pa3wzh0w54k3ea49u6rqdhv")
 
 	req.URL.RawQuery = q.Encode()
 
 	rr := httptest.NewRecorder()
-	handler := http.HandlerFunc(hdl.GetDebondingDelegations)
+	handler := http.HandlerFunc(hdl.GetDebondingDelegationsFor)
 	handler.ServeHTTP(rr, req)
 	if status := rr.Code; status != http.StatusOK {
 		t.Errorf("handler returned wrong status code: got %v want %v",
@@ -714,7 +714,7 @@ func Test_GetEvents_Height3(t *testing.T) {
 	expected := "result"
 
 	events := &responses.StakingEvents{
-		StakingEvents: []staking_api.Event{},
+		StakingEvents: []*staking_api.Event{},
 	}
 
 	err := json.Unmarshal([]byte(rr.Body.String()), events)
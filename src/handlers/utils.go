@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"encoding/json"
+	"mime"
+	"net/http"
 	"strconv"
 	"sync"
 
@@ -32,6 +35,27 @@ func checkSentryData(nodeName string) (bool, string, string) {
 	return false, "", ""
 }
 
+// Function to check if signer name is in configuration and return its
+// entity directory and gas price
+func checkSignerName(signerName string) (bool, string, string) {
+
+	// Check if signerName is in configuration
+	for _, signer := range config.GetSigners() {
+
+		// If signerName is in configuration reply with it's entity directory
+		if signer["signer_name"] == signerName {
+			lgr.Info.Printf("Requested signer %s was found!",
+				signerName)
+			return true, signer["entity_dir"], signer["gas_price"]
+		}
+	}
+
+	// If signerName isn't in configuration produce Log and Reply with False
+	lgr.Error.Printf("Requested signer %s was not found, check if "+
+		"configured!", signerName)
+	return false, "", ""
+}
+
 // Function to check if node name is in configuration and return socket for it
 func checkNodeName(nodeName string) (bool, string) {
 	mutex := &sync.RWMutex{}
@@ -115,6 +139,26 @@ func checkHeight(recvHeight string) int64 {
 	return height
 }
 
+// Function to check if round is valid, empty round refers to latest round
+func checkRound(recvRound string) (uint64, bool, bool) {
+
+	// If string is empty meaning no optional parameter was passed
+	// use latest round
+	if len(recvRound) == 0 {
+		lgr.Info.Println("No round specified getting latest round!")
+		return 0, true, true
+	}
+
+	// If round isn't empty attempt to parse it into uint64
+	round, err := strconv.ParseUint(recvRound, 10, 64)
+	if err != nil {
+		lgr.Error.Println("Unexpected value found, required "+
+			"string of int but received ", recvRound)
+		return 0, false, false
+	}
+	return round, false, true
+}
+
 // Function to check if Kind is valid
 func checkKind(recvKind string) int64 {
 
@@ -196,3 +240,22 @@ func getNodeExporter() (bool, string) {
 	mutex.Unlock()
 	return false, ""
 }
+
+// Function to write single JSON object of a stream and flush it to client
+func streamJSON(w http.ResponseWriter, v interface{}) {
+	json.NewEncoder(w).Encode(v)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// jsonRequest returns whether request was sent with JSON content type, or
+// made internally through router on behalf of such a request
+func jsonRequest(r *http.Request) bool {
+	if internal, _ := r.Context().Value(
+		internalContext{}).(bool); internal {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/responses"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// v2Parameters maps names of v1 parameters to their consistent v2 names,
+// parameters not listed keep their name
+var v2Parameters = map[string]string{
+	"name":                 "node",
+	"ownerKey":             "address",
+	"nodeID":               "node_id",
+	"entity":               "entity_id",
+	"entityID":             "entity_id",
+	"namespace":            "runtime_id",
+	"id":                   "proposal_id",
+	"consensus_public_key": "public_key",
+}
+
+// v2Paths maps v1 paths that are named inconsistently to their v2 paths,
+// other v1 paths are served under /api/v2 with the same name
+var v2Paths = map[string]string{
+	"/api/getconnectionslist":            "/api/v2/connections",
+	"/api/pingnode":                      "/api/v2/consensus/ping",
+	"/api/staking/accountinfo":           "/api/v2/staking/account",
+	"/api/consensus/pubkeyaddress":       "/api/v2/consensus/tendermintaddress",
+	"/api/consensus/pubkeybech32address": "/api/v2/consensus/address",
+	"/api/consensus/base64bech32address": "/api/v2/consensus/base64address",
+}
+
+// v2Excluded holds v1 routes that have no v2 counterpart, streams can't be
+// wrapped in an envelope
+var v2Excluded = map[string]bool{
+	"/api/batch":        true,
+	"/api/openapi.json": true,
+}
+
+// Names of staking threshold kinds accepted by v2
+var thresholdKinds = []string{
+	staking.KindEntityName,
+	staking.KindNodeValidatorName,
+	staking.KindNodeComputeName,
+	staking.KindNodeStorageName,
+	staking.KindNodeKeyManagerName,
+	staking.KindRuntimeComputeName,
+	staking.KindRuntimeKeyManagerName,
+}
+
+// v2ParameterName returns v2 name of v1 parameter
+func v2ParameterName(name string) string {
+	if renamed, ok := v2Parameters[name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// v2Routes returns v2 counterparts of v1 routes, answered by v1 routes and
+// wrapped in an envelope
+func v2Routes(routes []*Route) []*Route {
+	v2 := []*Route{}
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/api/") ||
+			v2Excluded[route.Path] || StreamingPaths[route.Path] {
+			continue
+		}
+
+		path, ok := v2Paths[route.Path]
+		if !ok {
+			path = "/api/v2" + strings.TrimPrefix(route.Path, "/api")
+		}
+
+		parameters := []Parameter{}
+		for _, param := range route.Parameters {
+			param.Name = v2ParameterName(param.Name)
+			if param.Name == "kind" {
+				param.Type = "string"
+				param.Description = "Kind of threshold, one of " +
+					strings.Join(thresholdKinds, ", ")
+			}
+			parameters = append(parameters, param)
+		}
+
+		// Read routes also take parameters as JSON object body of POST
+		methods := route.Methods
+		if len(methods) == 1 && methods[0] == http.MethodGet {
+			methods = []string{http.MethodGet, http.MethodPost}
+		}
+
+		v2 = append(v2, &Route{
+			Path:       path,
+			Methods:    methods,
+			Group:      route.Group,
+			Summary:    route.Summary,
+			Parameters: parameters,
+			Scopes:     route.Scopes,
+			Response:   responses.Envelope{},
+			NewHandler: v2Handler(path, route),
+			Enabled:    route.Enabled,
+			Immutable:  route.Immutable,
+		})
+	}
+	return v2
+}
+
+// v2Result returns result of v1 response body, bodies holding more than a
+// result are returned whole
+func v2Result(body map[string]json.RawMessage,
+	raw []byte) json.RawMessage {
+
+	if len(body) == 1 {
+		if result, ok := body["result"]; ok {
+			return result
+		}
+		if result, ok := body["results"]; ok {
+			return result
+		}
+	}
+	return json.RawMessage(raw)
+}
+
+// v2LatestHeight returns latest height of node together with node of group
+// that answered, or error message if it can't be retrieved
+func v2LatestHeight(ctx context.Context, router http.Handler,
+	node string) (int64, string, string) {
+
+	rec, err := serveInternal(pinningRequest(ctx), router, http.MethodGet,
+		"/api/consensus/height", url.Values{"name": {node}})
+	if err != nil || rec.status != http.StatusOK {
+		return 0, "", "Failed to retrieve latest height!"
+	}
+
+	body := map[string]json.RawMessage{}
+	json.Unmarshal(rec.body.Bytes(), &body)
+	if message, ok := body["error"]; ok {
+		var text string
+		json.Unmarshal(message, &text)
+		return 0, "", text
+	}
+	var height int64
+	if err = json.Unmarshal(body["result"], &height); err != nil ||
+		height <= 0 {
+		return 0, "", "Failed to retrieve latest height!"
+	}
+	return height, rec.header.Get(BackendHeader), ""
+}
+
+// v2Handler returns handler of v2 route at path answered by v1 route,
+// queries at latest height are pinned so that height is always reported
+func v2Handler(path string,
+	route *Route) func(http.Handler) http.HandlerFunc {
+
+	// Map v2 parameter names back to names used by v1
+	v1Names := map[string]string{}
+	hasHeight := false
+	for _, param := range route.Parameters {
+		v1Names[v2ParameterName(param.Name)] = param.Name
+		if param.Name == "height" {
+			hasHeight = true
+		}
+	}
+
+	return func(router http.Handler) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+
+			// Add header so that received knows they're receiving JSON
+			w.Header().Add("Content-Type", "application/json")
+
+			// Parameters are read from query, and from JSON object body of
+			// POST requests
+			params := r.URL.Query()
+			if r.Method == http.MethodPost && !jsonRequest(r) {
+				json.NewEncoder(w).Encode(responses.Envelope{
+					Error: "Unexpected Content-Type, requests need to be " +
+						"sent as application/json!"})
+				return
+			}
+			if r.Method == http.MethodPost && r.ContentLength != 0 {
+				var body json.RawMessage
+				err := json.NewDecoder(r.Body).Decode(&body)
+				values, ok := rpcParams(body)
+				if err != nil || !ok {
+					json.NewEncoder(w).Encode(responses.Envelope{
+						Error: "Invalid body, body needs to be an object " +
+							"of strings, numbers or booleans!"})
+					return
+				}
+				for key := range values {
+					params.Set(key, values.Get(key))
+				}
+			}
+
+			query := url.Values{}
+			for name, values := range params {
+				v1Name, ok := v1Names[name]
+				if !ok {
+					json.NewEncoder(w).Encode(responses.Envelope{
+						Error: "Unknown parameter " + name + "!"})
+					return
+				}
+				query[v1Name] = values
+			}
+
+			// Threshold kinds are named in v2 and numbered in v1
+			if recvKind := query.Get("kind"); len(recvKind) > 0 {
+				var kind staking.ThresholdKind
+				if err := kind.UnmarshalText([]byte(recvKind)); err != nil {
+					json.NewEncoder(w).Encode(responses.Envelope{
+						Error: "Unexpected value found, kind needs to be " +
+							"one of " + strings.Join(thresholdKinds, ", ") +
+							"!"})
+					return
+				}
+				query.Set("kind", strconv.Itoa(int(kind)))
+			}
+
+			envelope := responses.Envelope{Node: query.Get("name")}
+
+			// Pin latest height, on node of group that will answer query
+			if hasHeight && len(envelope.Node) > 0 {
+				height := checkHeight(query.Get("height"))
+				if height == -1 {
+					json.NewEncoder(w).Encode(responses.Envelope{
+						Error: "Unexpected value found, height needs to " +
+							"be a string representing an int!",
+						Node: envelope.Node})
+					return
+				}
+				if height <= 0 {
+					latest, backend, message := v2LatestHeight(r.Context(),
+						router, envelope.Node)
+					if len(message) > 0 {
+						json.NewEncoder(w).Encode(responses.Envelope{
+							Error: message, Node: envelope.Node})
+						return
+					}
+					if len(backend) > 0 {
+						envelope.Node = backend
+						query.Set("name", backend)
+					}
+					height = latest
+					query.Set("height", strconv.FormatInt(height, 10))
+				}
+				envelope.Height = height
+			}
+
+			// v1 route is called with its own method, read routes being
+			// served only to GET
+			rec, err := serveInternal(r.Context(), router, route.Methods[0],
+				route.Path, query)
+			// Routes the API key may not access, or limited routes,
+			// answer with an error
+			if err != nil || (rec.status != http.StatusOK &&
+				rec.status != http.StatusForbidden &&
+				rec.status != http.StatusTooManyRequests) ||
+				!json.Valid(rec.body.Bytes()) {
+				lgr.Error.Println("Request at "+path+" failed to retrieve "+
+					"result of "+route.Path+" : ", err)
+				envelope.Error = "Failed to retrieve result!"
+				json.NewEncoder(w).Encode(envelope)
+				return
+			}
+			if backend := rec.header.Get(BackendHeader); len(backend) > 0 {
+				envelope.Node = backend
+			}
+
+			// Handlers answer with either an error or a result object
+			body := map[string]json.RawMessage{}
+			json.Unmarshal(rec.body.Bytes(), &body)
+			if message, ok := body["error"]; ok && len(body) == 1 {
+				json.Unmarshal(message, &envelope.Error)
+				envelope.Height = 0
+
+				// Keep status of forbidden and limited requests, so clients
+				// know to stop or when to retry
+				if rec.status != http.StatusOK {
+					if wait := rec.header.Get("Retry-After"); len(wait) > 0 {
+						w.Header().Set("Retry-After", wait)
+					}
+					w.WriteHeader(rec.status)
+				}
+				json.NewEncoder(w).Encode(envelope)
+				return
+			}
+			envelope.Result = v2Result(body, rec.body.Bytes())
+
+			lgr.Info.Println("Request at " + path + " responding with " +
+				"result!")
+			json.NewEncoder(w).Encode(envelope)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	hdl "github.com/SimplyVC/oasis_api_server/src/handlers"
+)
+
+// v2Handler returns handler of v2 route at path answered through v1 routes
+// of the API
+func v2Handler(t *testing.T, path string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ping", hdl.Pong)
+	mux.HandleFunc("/api/consensus/height", hdl.GetHeight)
+	mux.HandleFunc("/api/staking/threshold", hdl.GetThreshold)
+
+	for _, route := range hdl.Routes() {
+		if route.Path == path {
+			return route.NewHandler(mux)
+		}
+	}
+	t.Fatalf("Route %s not found", path)
+	return nil
+}
+
+func Test_V2_Ping(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v2/ping", nil)
+
+	rr := httptest.NewRecorder()
+	v2Handler(t, "/api/v2/ping").ServeHTTP(rr, req)
+
+	expected := `{"result":"pong"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_UnknownParameter(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v2/staking/threshold", nil)
+	q := req.URL.Query()
+	q.Add("name", "Oasis_Local")
+	req.URL.RawQuery = q.Encode()
+
+	rr := httptest.NewRecorder()
+	v2Handler(t, "/api/v2/staking/threshold").ServeHTTP(rr, req)
+
+	expected := `{"error":"Unknown parameter name!"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_InvalidKind(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v2/staking/threshold", nil)
+	q := req.URL.Query()
+	q.Add("node", "Oasis_Local")
+	q.Add("kind", "1")
+	req.URL.RawQuery = q.Encode()
+
+	rr := httptest.NewRecorder()
+	v2Handler(t, "/api/v2/staking/threshold").ServeHTTP(rr, req)
+
+	expected := `{"error":"Unexpected value found, kind needs to be one of entity, node-validator, node-compute, node-storage, node-keymanager, runtime-compute, runtime-keymanager!"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_BadNode(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v2/staking/threshold", nil)
+	q := req.URL.Query()
+	q.Add("node", "Unicorn")
+	q.Add("kind", "entity")
+	req.URL.RawQuery = q.Encode()
+
+	rr := httptest.NewRecorder()
+	v2Handler(t, "/api/v2/staking/threshold").ServeHTTP(rr, req)
+
+	expected := `{"error":"Node name requested doesn't exist","node":"Unicorn"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_NumericBody(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/governance/proposal", echoQuery)
+
+	var handler http.Handler
+	for _, route := range hdl.Routes() {
+		if route.Path == "/api/v2/governance/proposal" {
+			handler = route.NewHandler(mux)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", "/api/v2/governance/proposal",
+		strings.NewReader(`{"node":"Oasis_Local","height":5000000,`+
+			`"proposal_id":12345678}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"result":{"height":["5000000"],"id":["12345678"],` +
+		`"name":["Oasis_Local"]},"height":5000000,"node":"Oasis_Local"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_TooManyRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"Too many requests, retry later!"}`))
+	})
+
+	var handler http.Handler
+	for _, route := range hdl.Routes() {
+		if route.Path == "/api/v2/ping" {
+			handler = route.NewHandler(mux)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v2/ping", nil)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Errorf("handler returned status %d want %d", rr.Code,
+			http.StatusTooManyRequests)
+	}
+	if wait := rr.Header().Get("Retry-After"); wait != "3" {
+		t.Errorf("handler returned Retry-After %q want %q", wait, "3")
+	}
+
+	expected := `{"error":"Too many requests, retry later!"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
+
+func Test_V2_PostReadRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/ping", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		hdl.Pong(w, r)
+	})
+
+	var handler http.Handler
+	for _, route := range hdl.Routes() {
+		if route.Path == "/api/v2/ping" {
+			if len(route.Methods) != 2 ||
+				route.Methods[1] != http.MethodPost {
+				t.Errorf("Route %s has methods %v", route.Path,
+					route.Methods)
+			}
+			handler = route.NewHandler(mux)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", "/api/v2/ping", strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	expected := `{"result":"pong"}`
+
+	if strings.TrimSpace(rr.Body.String()) != strings.TrimSpace(expected) {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rr.Body.String(), expected)
+	}
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/SimplyVC/oasis_api_server/src/cache"
+)
+
+// Descriptions of response cache metrics
+var (
+	cacheHitsDesc = prometheus.NewDesc(namespace+"_cache_hits_total",
+		"Requests answered from response cache.", nil, nil)
+	cacheMissesDesc = prometheus.NewDesc(namespace+"_cache_misses_total",
+		"Requests not found in response cache.", nil, nil)
+	cacheDiskHitsDesc = prometheus.NewDesc(
+		namespace+"_cache_disk_hits_total",
+		"Requests answered from disk cache.", nil, nil)
+	cacheEvictionsDesc = prometheus.NewDesc(
+		namespace+"_cache_evictions_total",
+		"Responses evicted from response cache.", nil, nil)
+	cacheHitRatioDesc = prometheus.NewDesc(namespace+"_cache_hit_ratio",
+		"Share of cacheable requests answered from cache.", nil, nil)
+	cacheEntriesDesc = prometheus.NewDesc(namespace+"_cache_entries",
+		"Responses held in memory by response cache.", nil, nil)
+	cacheBytesDesc = prometheus.NewDesc(namespace+"_cache_bytes",
+		"Bytes of responses held in memory by response cache.", nil, nil)
+)
+
+// cacheCollector exports statistics of response cache when it's enabled
+type cacheCollector struct {
+	mutex sync.RWMutex
+	stats func() cache.Stats
+}
+
+var responseCache = &cacheCollector{}
+
+func init() {
+	prometheus.MustRegister(responseCache)
+}
+
+// SetCacheStats sets function returning statistics of response cache
+func SetCacheStats(stats func() cache.Stats) {
+	responseCache.mutex.Lock()
+	defer responseCache.mutex.Unlock()
+	responseCache.stats = stats
+}
+
+// Describe sends descriptions of response cache metrics
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{cacheHitsDesc, cacheMissesDesc,
+		cacheDiskHitsDesc, cacheEvictionsDesc, cacheHitRatioDesc,
+		cacheEntriesDesc, cacheBytesDesc} {
+		ch <- desc
+	}
+}
+
+// Collect sends current statistics of response cache
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.RLock()
+	statsFunc := c.stats
+	c.mutex.RUnlock()
+	if statsFunc == nil {
+		return
+	}
+
+	stats := statsFunc()
+	ratio := 0.0
+	if stats.Hits+stats.Misses > 0 {
+		ratio = float64(stats.Hits) / float64(stats.Hits+stats.Misses)
+	}
+	ch <- prometheus.MustNewConstMetric(cacheHitsDesc,
+		prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(cacheMissesDesc,
+		prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(cacheDiskHitsDesc,
+		prometheus.CounterValue, float64(stats.DiskHits))
+	ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc,
+		prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(cacheHitRatioDesc,
+		prometheus.GaugeValue, ratio)
+	ch <- prometheus.MustNewConstMetric(cacheEntriesDesc,
+		prometheus.GaugeValue, float64(stats.Entries))
+	ch <- prometheus.MustNewConstMetric(cacheBytesDesc,
+		prometheus.GaugeValue, float64(stats.Bytes))
+}
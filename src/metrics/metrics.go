@@ -0,0 +1,110 @@
+// Package metrics holds Prometheus metrics of the API server itself. They
+// are served together with metrics of gRPC clients and of the Go runtime.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace of metrics of the API server
+const namespace = "oasis_api"
+
+// Metrics of request limits
+var (
+	// RateLimited counts requests rejected by rate limit of client, clients
+	// without API key are counted as anonymous
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by rate limit of client.",
+	}, []string{"client"})
+
+	// CallsRejected counts requests and gRPC calls rejected as too many
+	// calls to node, or to all nodes, were outstanding
+	CallsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "calls_rejected_total",
+		Help:      "Requests and gRPC calls rejected by concurrency limits.",
+	}, []string{"node"})
+
+	// CallsOutstanding is number of gRPC calls to node awaiting response
+	CallsOutstanding = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "calls_outstanding",
+		Help:      "gRPC calls to node awaiting response.",
+	}, []string{"node"})
+)
+
+// Metrics of requests served by the API server
+var (
+	// Requests counts requests answered on route with status
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Requests answered by route, method and status.",
+	}, []string{"route", "method", "status"})
+
+	// RequestDuration is time taken to answer requests on route
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Time taken to answer requests by route, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+)
+
+// Metrics of gRPC calls to nodes
+var (
+	// CallDuration is time taken by gRPC calls to node
+	CallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "call_duration_seconds",
+		Help:      "Time taken by gRPC calls by node and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"node", "method"})
+
+	// CallErrors counts gRPC calls to node that failed, by status code
+	CallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "call_errors_total",
+		Help:      "Failed gRPC calls by node, method and status code.",
+	}, []string{"node", "method", "code"})
+
+	// Connections counts gRPC connections opened to node
+	Connections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "connections_total",
+		Help:      "gRPC connections opened to node.",
+	}, []string{"node"})
+
+	// ConnectionsOpen is number of gRPC transports connected to node
+	ConnectionsOpen = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "connections_open",
+		Help:      "gRPC transports currently connected to node.",
+	}, []string{"node"})
+)
+
+// Metrics of configured nodes
+var (
+	// LatestHeight is latest block height seen by health checks of node
+	LatestHeight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "node_latest_height",
+		Help:      "Latest block height seen on node.",
+	}, []string{"node"})
+)
+
+func init() {
+	prometheus.MustRegister(RateLimited, CallsRejected, CallsOutstanding,
+		Requests, RequestDuration, CallDuration, CallErrors, Connections,
+		ConnectionsOpen, LatestHeight)
+}
+
+// Handler serves all registered metrics in Prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -1,22 +1,34 @@
 package responses
 
 import (
+	"encoding/json"
+	"time"
+
+	"github.com/SimplyVC/oasis_api_server/src/cache"
 	"github.com/mackerelio/go-osstat/cpu"
 	"github.com/mackerelio/go-osstat/memory"
 	"github.com/mackerelio/go-osstat/network"
+	common_namespace "github.com/oasisprotocol/oasis-core/go/common"
+	common_hash "github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	common_signature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	common_entity "github.com/oasisprotocol/oasis-core/go/common/entity"
 	common_node "github.com/oasisprotocol/oasis-core/go/common/node"
 	common_quantity "github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus_api "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	consensus_transaction "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	control_api "github.com/oasisprotocol/oasis-core/go/control/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 
 	//epoch_api "github.com/oasisprotocol/oasis-core/go/epochtime/api"
 	beacon_api "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	gen_api "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	registry_api "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash_api "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	roothash_block "github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	scheduler_api "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	sentry_api "github.com/oasisprotocol/oasis-core/go/sentry/api"
 	staking_api "github.com/oasisprotocol/oasis-core/go/staking/api"
+	upgrade_api "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	tmed "github.com/tendermint/tendermint/crypto"
 	mint_types "github.com/tendermint/tendermint/types"
 )
@@ -242,5 +254,314 @@ type VotesResponse struct {
 	Votes []*governance.VoteEntry `json:"result"`
 }
 
+// RootHashEventsResponse responds with roothash events at specified height
+type RootHashEventsResponse struct {
+	Events []*roothash_api.Event `json:"result"`
+}
+
+// RuntimeBlocksResponse responds with a page of runtime blocks and round
+// at which next page starts
+type RuntimeBlocksResponse struct {
+	Blocks    []*roothash_block.Block `json:"result"`
+	NextRound *uint64                 `json:"next_round,omitempty"`
+}
+
+// CommitteeMembership is a single committee that a node is a member of
+type CommitteeMembership struct {
+	NodeID    common_signature.PublicKey  `json:"node_id"`
+	RuntimeID common_namespace.Namespace  `json:"runtime_id"`
+	Kind      scheduler_api.CommitteeKind `json:"kind"`
+	Role      scheduler_api.Role          `json:"role"`
+	ValidFor  beacon_api.EpochTime        `json:"valid_for"`
+}
+
+// EpochCommittees holds committee memberships for an epoch, height is
+// first block of epoch or the height at which it is scheduled to start
+type EpochCommittees struct {
+	Epoch       beacon_api.EpochTime   `json:"epoch"`
+	Height      int64                  `json:"height,omitempty"`
+	Elected     bool                   `json:"elected"`
+	Memberships []*CommitteeMembership `json:"memberships"`
+}
+
+// NodeCommittees holds committee memberships of current and next epoch
+type NodeCommittees struct {
+	Current *EpochCommittees `json:"current"`
+	Next    *EpochCommittees `json:"next,omitempty"`
+}
+
+// NodeCommitteesResponse responds with committee memberships of a node
+type NodeCommitteesResponse struct {
+	NodeCommittees *NodeCommittees `json:"result"`
+}
+
+// ValidatorCandidate is an entity running validator nodes, ranked by its
+// escrow balance, margin is a signed amount relative to the cutoff
+type ValidatorCandidate struct {
+	Rank             int                          `json:"rank"`
+	EntityID         common_signature.PublicKey   `json:"entity_id"`
+	Address          staking_api.Address          `json:"address"`
+	EscrowBalance    common_quantity.Quantity     `json:"escrow_balance"`
+	ValidatorNodes   []common_signature.PublicKey `json:"validator_nodes"`
+	InValidatorSet   bool                         `json:"in_validator_set"`
+	ProjectedElected bool                         `json:"projected_elected"`
+	Slots            int                          `json:"slots"`
+	Margin           string                       `json:"margin"`
+}
+
+// ValidatorOutlook holds validator set limits and ranked candidates
+type ValidatorOutlook struct {
+	MinValidators          int                      `json:"min_validators"`
+	MaxValidators          int                      `json:"max_validators"`
+	MaxValidatorsPerEntity int                      `json:"max_validators_per_entity"`
+	ProjectedValidators    int                      `json:"projected_validators"`
+	CutoffBalance          common_quantity.Quantity `json:"cutoff_balance"`
+	Candidates             []*ValidatorCandidate    `json:"candidates"`
+}
+
+// ValidatorOutlookResponse responds with validator election outlook
+type ValidatorOutlookResponse struct {
+	Outlook *ValidatorOutlook `json:"result"`
+}
+
+// EpochTransition is height and time at which an epoch starts, estimated
+// for epochs that have not started yet
+type EpochTransition struct {
+	Epoch     beacon_api.EpochTime `json:"epoch"`
+	Height    int64                `json:"height"`
+	Time      time.Time            `json:"time"`
+	Estimated bool                 `json:"estimated"`
+}
+
+// EpochTiming holds current epoch, epoch interval in blocks and average
+// block time in seconds together with projected epoch transitions
+type EpochTiming struct {
+	Epoch            beacon_api.EpochTime `json:"epoch"`
+	StartHeight      int64                `json:"start_height"`
+	Interval         int64                `json:"interval"`
+	LatestHeight     int64                `json:"latest_height"`
+	LatestTime       time.Time            `json:"latest_time"`
+	AverageBlockTime float64              `json:"average_block_time"`
+	Transitions      []*EpochTransition   `json:"transitions,omitempty"`
+}
+
+// EpochTimingResponse responds with epoch timing
+type EpochTimingResponse struct {
+	Timing *EpochTiming `json:"result"`
+}
+
+// EpochTransitionResponse responds with start of an epoch
+type EpochTransitionResponse struct {
+	Transition *EpochTransition `json:"result"`
+}
+
+// BeaconState holds random beacon state and PVSS round state if beacon is
+// running a PVSS round
+type BeaconState struct {
+	Epoch       beacon_api.EpochTime            `json:"epoch"`
+	BaseEpoch   beacon_api.EpochTime            `json:"base_epoch"`
+	FutureEpoch *beacon_api.EpochTimeState      `json:"future_epoch,omitempty"`
+	Beacon      []byte                          `json:"beacon"`
+	Parameters  *beacon_api.ConsensusParameters `json:"parameters"`
+	PVSSState   *beacon_api.PVSSState           `json:"pvss_state,omitempty"`
+}
+
+// BeaconStateResponse responds with random beacon state
+type BeaconStateResponse struct {
+	BeaconState *BeaconState `json:"result"`
+}
+
+// VoterStake holds escrow of validator entity eligible to vote
+type VoterStake struct {
+	EntityID common_signature.PublicKey `json:"entity_id"`
+	Address  staking_api.Address        `json:"address"`
+	Escrow   common_quantity.Quantity   `json:"escrow"`
+}
+
+// ProposalTally holds votes of proposal weighted by escrow of validator
+// entities and whether quorum and threshold are met
+type ProposalTally struct {
+	ProposalID       uint64                   `json:"proposal_id"`
+	State            governance.ProposalState `json:"state"`
+	Height           int64                    `json:"height"`
+	Yes              common_quantity.Quantity `json:"yes"`
+	No               common_quantity.Quantity `json:"no"`
+	Abstain          common_quantity.Quantity `json:"abstain"`
+	VotedStake       common_quantity.Quantity `json:"voted_stake"`
+	TotalVotingStake common_quantity.Quantity `json:"total_voting_stake"`
+	Participation    uint64                   `json:"participation"`
+	YesPercentage    uint64                   `json:"yes_percentage"`
+	Quorum           uint8                    `json:"quorum"`
+	Threshold        uint8                    `json:"threshold"`
+	QuorumReached    bool                     `json:"quorum_reached"`
+	ThresholdReached bool                     `json:"threshold_reached"`
+	InvalidVotes     uint64                   `json:"invalid_votes"`
+	NonVoters        []*VoterStake            `json:"non_voters"`
+}
+
+// ProposalTallyResponse responds with tally of proposal votes
+type ProposalTallyResponse struct {
+	Tally *ProposalTally `json:"result"`
+}
+
+// NodeUpgradeReadiness holds whether configured node is ready for upgrade
+type NodeUpgradeReadiness struct {
+	NodeName           string                   `json:"node_name"`
+	SoftwareVersion    string                   `json:"software_version,omitempty"`
+	Ready              bool                     `json:"ready"`
+	UpgradeHeight      int64                    `json:"upgrade_height,omitempty"`
+	LastCompletedStage upgrade_api.UpgradeStage `json:"last_completed_stage,omitempty"`
+	Error              string                   `json:"error,omitempty"`
+}
+
+// PendingUpgrade holds upgrade accepted by governance that is yet to be
+// executed together with its estimated time and readiness of nodes
+type PendingUpgrade struct {
+	Descriptor *upgrade_api.Descriptor `json:"descriptor"`
+	ETA        *EpochTransition        `json:"eta"`
+	Nodes      []*NodeUpgradeReadiness `json:"nodes"`
+}
+
+// PendingUpgradesResponse responds with pending network upgrades
+type PendingUpgradesResponse struct {
+	Upgrades []*PendingUpgrade `json:"result"`
+}
+
+// GovernanceParametersResponse responds with governance consensus parameters
+type GovernanceParametersResponse struct {
+	Parameters *governance.ConsensusParameters `json:"result"`
+}
+
+// ProposalTimeline holds submission and closing of proposal
+type ProposalTimeline struct {
+	ID           uint64                     `json:"id"`
+	Submitter    staking_api.Address        `json:"submitter"`
+	Deposit      common_quantity.Quantity   `json:"deposit"`
+	State        governance.ProposalState   `json:"state"`
+	Content      governance.ProposalContent `json:"content"`
+	CurrentEpoch beacon_api.EpochTime       `json:"current_epoch"`
+	Submitted    *EpochTransition           `json:"submitted"`
+	Closes       *EpochTransition           `json:"closes"`
+}
+
+// ProposalTimelineResponse responds with timeline of proposal
+type ProposalTimelineResponse struct {
+	Timeline *ProposalTimeline `json:"result"`
+}
+
+// CastVoteResult holds vote transaction and its hash once submitted
+type CastVoteResult struct {
+	Signer      common_signature.PublicKey         `json:"signer"`
+	Transaction *consensus_transaction.Transaction `json:"transaction"`
+	Submitted   bool                               `json:"submitted"`
+	Hash        *common_hash.Hash                  `json:"hash,omitempty"`
+}
+
+// CastVoteResponse responds with cast vote transaction
+type CastVoteResponse struct {
+	CastVote *CastVoteResult `json:"result"`
+}
+
+// ControlStatus holds status overview of node and status of key manager
+// runtimes it is running
+type ControlStatus struct {
+	*control_api.Status
+	KeyManagers map[common_namespace.Namespace]control_api.RuntimeStatus `json:"keymanagers"`
+}
+
+// ControlStatusResponse responds with status overview of node
+type ControlStatusResponse struct {
+	Status *ControlStatus `json:"result"`
+}
+
+// WaitProgress holds progress of node towards being ready
+type WaitProgress struct {
+	Height        int64 `json:"height"`
+	NetworkHeight int64 `json:"network_height,omitempty"`
+	Synced        bool  `json:"synced"`
+	Ready         bool  `json:"ready"`
+}
+
+// WaitProgressResponse responds with progress of node towards being ready
+type WaitProgressResponse struct {
+	Progress *WaitProgress `json:"result"`
+}
+
+// NodeConsistency holds consensus state of configured node compared to
+// other configured nodes
+type NodeConsistency struct {
+	NodeName         string `json:"node_name"`
+	Reachable        bool   `json:"reachable"`
+	LatestHeight     int64  `json:"latest_height,omitempty"`
+	ChainContext     string `json:"chain_context,omitempty"`
+	GenesisHash      []byte `json:"genesis_hash,omitempty"`
+	CommonBlockHash  []byte `json:"common_block_hash,omitempty"`
+	Lag              int64  `json:"lag"`
+	Lagging          bool   `json:"lagging"`
+	Forked           bool   `json:"forked"`
+	DifferentNetwork bool   `json:"different_network"`
+	Error            string `json:"error,omitempty"`
+}
+
+// ConsistencyReport holds consistency of all configured nodes
+type ConsistencyReport struct {
+	ChainContext string             `json:"chain_context"`
+	CommonHeight int64              `json:"common_height"`
+	MaxHeight    int64              `json:"max_height"`
+	Consistent   bool               `json:"consistent"`
+	Nodes        []*NodeConsistency `json:"nodes"`
+}
+
+// ConsistencyReportResponse responds with consistency of configured nodes
+type ConsistencyReportResponse struct {
+	Report *ConsistencyReport `json:"result"`
+}
+
+// CacheStatsResponse responds with statistics of response cache
+type CacheStatsResponse struct {
+	Stats *cache.Stats `json:"result"`
+}
+
+// BatchResult holds response of sub-request of batch
+type BatchResult struct {
+	Path     string          `json:"path"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// Batch holds responses of all sub-requests of batch in order
+type Batch struct {
+	Height  int64          `json:"height,omitempty"`
+	Results []*BatchResult `json:"results"`
+}
+
+// BatchResponse responds with results of batch
+type BatchResponse struct {
+	Batch *Batch `json:"result"`
+}
+
+// RPCError is error of JSON-RPC 2.0 response
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// RPCResponse is a JSON-RPC 2.0 response
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// Envelope is response of v2 routes, holding result or error together with
+// height data was read at and node that served it
+type Envelope struct {
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	Height int64           `json:"height,omitempty"`
+	Node   string          `json:"node,omitempty"`
+}
+
 // SuccessResponsed Assinging Variable Responses that do not need to be changed.
 var SuccessResponsed = SuccessResponse{Result: "pong"}
@@ -1,17 +1,41 @@
 package router
 
 import (
+	"crypto/tls"
 	"log"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 
+	"github.com/SimplyVC/oasis_api_server/src/cache"
+	"github.com/SimplyVC/oasis_api_server/src/collector"
 	conf "github.com/SimplyVC/oasis_api_server/src/config"
 	handler "github.com/SimplyVC/oasis_api_server/src/handlers"
 	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+	"github.com/SimplyVC/oasis_api_server/src/rpc"
 	"github.com/zenazn/goji/graceful"
 )
 
+// RegisterRoutes registers handler of every route in route table of
+// handlers, the same table the OpenAPI specification is generated from
+func RegisterRoutes(router *mux.Router) {
+	for _, route := range handler.Routes() {
+		if route.Enabled != nil && !route.Enabled() {
+			continue
+		}
+		routeHandler := route.Handler
+		if route.NewHandler != nil {
+			routeHandler = route.NewHandler(router)
+		}
+		router.HandleFunc(route.Path, routeHandler).Methods(route.Methods...)
+	}
+}
+
 // StartServer starts server by setting router and all endpoints
 func StartServer() error {
 
@@ -23,137 +47,233 @@ func StartServer() error {
 		os.Exit(0)
 	}
 
-	// Load socket configuration but do not use them
-	_, err3 := conf.LoadNodesConfiguration()
+	// Load socket configuration, TLS settings of nodes are used below
+	nodesConf, err3 := conf.LoadNodesConfiguration()
 	if err3 != nil {
 		lgr.Error.Println("Loading of Socket configuration has failed!")
 		// Abort Program no Sockets configured to run API on
 		os.Exit(0)
 	}
 
+	// Register TLS settings of nodes reached over TCP
+	for _, node := range nodesConf {
+		if len(node["tls_ca_path"]) == 0 && len(node["tls_cert_path"]) == 0 &&
+			len(node["tls_server_name"]) == 0 {
+			continue
+		}
+		err := rpc.RegisterNodeTLS(node["isocket_path"], &rpc.TLSOptions{
+			CAPath:     node["tls_ca_path"],
+			ServerName: node["tls_server_name"],
+			CertPath:   node["tls_cert_path"],
+			KeyPath:    node["tls_key_path"],
+		})
+		if err != nil {
+			lgr.Error.Printf("Loading of TLS configuration for node %s "+
+				"has failed : %s", node["node_name"], err)
+			os.Exit(0)
+		}
+	}
+
 	// Load sentry configuration
 	_, err4 := conf.LoadSentryConfiguration()
 	if err4 != nil {
 		lgr.Error.Println("Loading of Sentry configuration has failed!")
 	}
 
+	// Load signers configuration, vote casting is disabled without it
+	_, err5 := conf.LoadSignersConfiguration()
+	if err5 != nil {
+		lgr.Info.Println("No Signers configured, vote casting disabled.")
+	}
+
+	// Load API keys configuration, API keys aren't required without it
+	keysConf, err6 := conf.LoadKeysConfiguration()
+	if err6 != nil {
+		lgr.Info.Println("No API keys configured, API keys not required.")
+	} else {
+		keys, err := handler.LoadAPIKeys(keysConf)
+		if err != nil {
+			lgr.Error.Println("Loading of API keys configuration has "+
+				"failed : ", err)
+			// Abort Program rather than serve without required API keys
+			os.Exit(0)
+		}
+		handler.SetAPIKeys(keys)
+	}
+
+	// Vote casting needs to be enabled explicitly, and API keys configured
+	if mainConf["api_server"]["enable_vote_casting"] == "true" {
+		if err6 != nil {
+			lgr.Error.Println("Vote casting is enabled but no API keys " +
+				"are configured, vote casting disabled.")
+		} else {
+			handler.SetVoteCasting(true)
+		}
+	}
+
 	apiPort := mainConf["api_server"]["port"]
 	lgr.Info.Println("Loaded port : ", apiPort)
 
+	// Load node group options, falling back to defaults if not configured
+	groupStrategy := mainConf["api_server"]["group_strategy"]
+	if groupStrategy != handler.StrategyLeastLatency {
+		groupStrategy = handler.StrategyRoundRobin
+	}
+	groupInterval, err := strconv.Atoi(
+		mainConf["api_server"]["group_check_interval"])
+	if err != nil || groupInterval <= 0 {
+		groupInterval = 15
+	}
+	groupMaxLag, err := strconv.ParseInt(
+		mainConf["api_server"]["group_max_lag"], 10, 64)
+	if err != nil || groupMaxLag < 0 {
+		groupMaxLag = 10
+	}
+	handler.StartGroupHealthChecks(groupStrategy,
+		time.Duration(groupInterval)*time.Second, groupMaxLag)
+
+	// Load request limits, rate limiting and call limits are disabled if
+	// not configured
+	rateLimit, err := handler.ParseRateLimit(
+		mainConf["api_server"]["rate_limit"],
+		mainConf["api_server"]["rate_burst"])
+	if err != nil {
+		rateLimit = handler.RateLimit{}
+	}
+	handler.SetRateLimit(rateLimit)
+	maxCalls, err := strconv.Atoi(mainConf["api_server"]["max_calls"])
+	if err != nil || maxCalls < 0 {
+		maxCalls = 0
+	}
+	maxNodeCalls, err := strconv.Atoi(
+		mainConf["api_server"]["max_node_calls"])
+	if err != nil || maxNodeCalls < 0 {
+		maxNodeCalls = 0
+	}
+	rpc.SetCallLimits(maxCalls, maxNodeCalls)
+
+	// Load longest time requests may wait for node to become ready
+	maxWaitTimeout, err := strconv.Atoi(
+		mainConf["api_server"]["max_wait_timeout"])
+	if err == nil && maxWaitTimeout > 0 {
+		handler.SetMaxWaitTimeout(
+			time.Duration(maxWaitTimeout) * time.Second)
+	}
+
+	// Collect chain state metrics of nodes if interval is configured
+	chainInterval, err := strconv.Atoi(
+		mainConf["api_server"]["chain_metrics_interval"])
+	if err == nil && chainInterval > 0 {
+		chainWindow, err := strconv.Atoi(
+			mainConf["api_server"]["chain_metrics_window"])
+		if err != nil || chainWindow <= 0 {
+			chainWindow = 100
+		}
+		entities := []signature.PublicKey{}
+		for _, id := range strings.Split(
+			mainConf["api_server"]["chain_metrics_entities"], ",") {
+			if id = strings.TrimSpace(id); len(id) == 0 {
+				continue
+			}
+			var entity signature.PublicKey
+			if err := entity.UnmarshalText([]byte(id)); err != nil {
+				lgr.Error.Println("Loading of chain metrics entity "+id+
+					" has failed : ", err)
+				os.Exit(0)
+			}
+			entities = append(entities, entity)
+		}
+		collector.New(entities, chainWindow).Start(
+			time.Duration(chainInterval) * time.Second)
+	}
+
 	// Router object to handle requests
 	router := mux.NewRouter().StrictSlash(true)
 
-	// Router Handlers to handle General API Calls
-	router.HandleFunc("/api/ping", handler.Pong).Methods("Get")
-	router.HandleFunc("/api/getconnectionslist",
-		handler.GetConnections).Methods("Get")
-
-	// Router Handlers to handle Consensus API Calls
-	router.HandleFunc("/api/consensus/genesis",
-		handler.GetConsensusStateToGenesis).Methods("Get")
-	router.HandleFunc("/api/consensus/epoch",
-		handler.GetEpoch).Methods("Get")
-	router.HandleFunc("/api/consensus/block",
-		handler.GetBlock).Methods("Get")
-	router.HandleFunc("/api/consensus/status",
-		handler.GetStatus).Methods("Get")
-	router.HandleFunc("/api/consensus/height",
-		handler.GetHeight).Methods("Get")
-	router.HandleFunc("/api/consensus/blockheader",
-		handler.GetBlockHeader).Methods("Get")
-	router.HandleFunc("/api/consensus/blocklastcommit",
-		handler.GetBlockLastCommit).Methods("Get")
-	router.HandleFunc("/api/consensus/pubkeyaddress",
-		handler.PublicKeyToAddress).Methods("Get")
-	router.HandleFunc("/api/consensus/pubkeybech32address",
-		handler.PublicKeyToBech32Address).Methods("Get")
-	router.HandleFunc("/api/consensus/base64bech32address",
-		handler.Base64ToBech32Address).Methods("Get")
-	router.HandleFunc("/api/consensus/transactions",
-		handler.GetTransactions).Methods("Get")
-	router.HandleFunc("/api/consensus/transactionswithresults",
-		handler.GetTransactionsWithResults).Methods("Get")
-	router.HandleFunc("/api/pingnode",
-		handler.PingNode).Methods("Get")
-
-	// Router Handlers to handle Registry API Calls
-	router.HandleFunc("/api/registry/entities",
-		handler.GetEntities).Methods("Get")
-	router.HandleFunc("/api/registry/nodes",
-		handler.GetNodes).Methods("Get")
-	router.HandleFunc("/api/registry/nodestatus",
-		handler.GetNodeStatus).Methods("Get")
-	router.HandleFunc("/api/registry/events",
-		handler.GetRegistryEvents).Methods("Get")
-	router.HandleFunc("/api/registry/runtimes",
-		handler.GetRuntimes).Methods("Get")
-	router.HandleFunc("/api/registry/genesis",
-		handler.GetRegistryStateToGenesis).Methods("Get")
-	router.HandleFunc("/api/registry/entity",
-		handler.GetEntity).Methods("Get")
-	router.HandleFunc("/api/registry/node",
-		handler.GetNode).Methods("Get")
-	router.HandleFunc("/api/registry/runtime",
-		handler.GetRuntime).Methods("Get")
-
-	// Router Handlers to handle Staking API Calls
-	router.HandleFunc("/api/staking/totalsupply",
-		handler.GetTotalSupply).Methods("Get")
-	router.HandleFunc("/api/staking/commonpool",
-		handler.GetCommonPool).Methods("Get")
-	router.HandleFunc("/api/staking/genesis",
-		handler.GetStakingStateToGenesis).Methods("Get")
-	router.HandleFunc("/api/staking/threshold",
-		handler.GetThreshold).Methods("Get")
-	router.HandleFunc("/api/staking/accounts",
-		handler.GetAccounts).Methods("Get")
-	router.HandleFunc("/api/staking/accountinfo",
-		handler.GetAccountInfo).Methods("Get")
-	router.HandleFunc("/api/staking/delegations",
-		handler.GetDelegationsFor).Methods("Get")
-	router.HandleFunc("/api/staking/debondingdelegations",
-		handler.GetDebondingDelegationsFor).Methods("Get")
-	router.HandleFunc("/api/staking/events",
-		handler.GetEvents).Methods("Get")
-
-	// Router Handlers to handle NodeController API Calls
-	router.HandleFunc("/api/nodecontroller/synced",
-		handler.GetIsSynced).Methods("Get")
-
-	// Router Handlers to handle Scheduler API Calls
-	router.HandleFunc("/api/scheduler/validators",
-		handler.GetValidators).Methods("Get")
-	router.HandleFunc("/api/scheduler/committees",
-		handler.GetCommittees).Methods("Get")
-	router.HandleFunc("/api/scheduler/genesis",
-		handler.GetSchedulerStateToGenesis).Methods("Get")
-
-	// Router Handlers to handle Prometheus API Calls
-	router.HandleFunc("/api/prometheus/gauge",
-		handler.PrometheusQueryGauge).Methods("Get")
-	router.HandleFunc("/api/prometheus/counter",
-		handler.PrometheusQueryCounter).Methods("Get")
-
-	// Router Handlers to handle the Node Exporter API Calls
-	router.HandleFunc("/api/exporter/gauge",
-		handler.NodeExporterQueryGauge).Methods("Get")
-	router.HandleFunc("/api/exporter/counter",
-		handler.NodeExporterQueryCounter).Methods("Get")
-
-	// Router Handlers to handle Sentry API Calls
-	router.HandleFunc("/api/sentry/addresses",
-		handler.GetSentryAddresses).Methods("Get")
-
-	// Router Handlers to handle Governance API Calls
-	router.HandleFunc("/api/governance/activeproposals",
-		handler.GetActiveProposals).Methods("Get")
-	router.HandleFunc("/api/governance/proposals",
-		handler.GetProposals).Methods("Get")
-	router.HandleFunc("/api/governance/proposal",
-		handler.GetProposal).Methods("Get")
-	router.HandleFunc("/api/governance/votes",
-		handler.GetVotes).Methods("Get")
-
-	log.Fatal(graceful.ListenAndServe(":"+apiPort, router))
+	// Measure requests first so that rejected requests are counted too
+	router.Use(handler.RequestMetrics)
+
+	// Check API keys first so that cached responses require them too, and
+	// rate limits of keys can be applied
+	router.Use(handler.APIKeys)
+	router.Use(handler.RateLimits)
+
+	// Resolve node groups into healthy members before reaching handlers
+	router.Use(handler.NodeGroups)
+
+	// Load response cache options, falling back to defaults if not set
+	cacheSize, err := strconv.ParseInt(
+		mainConf["api_server"]["cache_size_mb"], 10, 64)
+	if err != nil || cacheSize < 0 {
+		cacheSize = 64
+	}
+	cacheTTL, err := strconv.Atoi(mainConf["api_server"]["cache_ttl"])
+	if err != nil || cacheTTL < 0 {
+		cacheTTL = 2
+	}
+	cacheDiskSize, err := strconv.ParseInt(
+		mainConf["api_server"]["cache_disk_size_mb"], 10, 64)
+	if err != nil || cacheDiskSize <= 0 {
+		cacheDiskSize = 1024
+	}
+	if cacheSize > 0 {
+		skip := map[string]bool{"/api/cache/stats": true, "/metrics": true}
+		for path := range handler.StreamingPaths {
+			skip[path] = true
+		}
+		responseCache := cache.New(cache.Options{
+			MaxBytes:     cacheSize * 1024 * 1024,
+			TTL:          time.Duration(cacheTTL) * time.Second,
+			Dir:          mainConf["api_server"]["cache_dir"],
+			MaxDiskBytes: cacheDiskSize * 1024 * 1024,
+			Skip:         skip,
+			Immutable:    handler.ImmutableRequest,
+			Private:      handler.APIKeysRequired,
+			Vary:         handler.APIKeyHeader,
+		})
+		handler.SetResponseCache(responseCache)
+		router.Use(responseCache.Middleware)
+	}
+
+	// Reject requests for nodes with too many calls outstanding, after
+	// cache so that cached responses are still served
+	router.Use(handler.CallLimits)
+
+	// Register handlers of all routes of route table
+	RegisterRoutes(router)
+
+	// Allow configured origins to call the API from browsers
+	handler.SetCORS(mainConf["api_server"]["cors_allowed_origins"],
+		mainConf["api_server"]["cors_allowed_methods"],
+		mainConf["api_server"]["cors_allowed_headers"])
+
+	address := net.JoinHostPort(mainConf["api_server"]["bind_address"],
+		apiPort)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		lgr.Error.Println("Listening on "+address+" has failed : ", err)
+		return err
+	}
+
+	// Serve HTTPS if certificate is configured, reloading it on change
+	certPath := mainConf["api_server"]["tls_cert_path"]
+	keyPath := mainConf["api_server"]["tls_key_path"]
+	if len(certPath) > 0 || len(keyPath) > 0 {
+		tlsConfig, reloader, err := serverTLSConfig(certPath, keyPath,
+			mainConf["api_server"]["tls_client_ca_path"])
+		if err != nil {
+			lgr.Error.Println("Loading of TLS configuration has failed : ",
+				err)
+			// Abort Program rather than serve without configured TLS
+			os.Exit(0)
+		}
+		go reloader.watch(certCheckInterval)
+		listener = tls.NewListener(listener, tlsConfig)
+		lgr.Info.Println("Serving HTTPS on " + address)
+	} else {
+		lgr.Info.Println("Serving HTTP on " + address)
+	}
+
+	log.Fatal(graceful.Serve(listener, handler.CORS(router)))
 	return nil
 }
@@ -0,0 +1,236 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	handler "github.com/SimplyVC/oasis_api_server/src/handlers"
+	"github.com/SimplyVC/oasis_api_server/src/router"
+)
+
+func TestRegisterRoutes_Documented(t *testing.T) {
+	r := mux.NewRouter()
+	router.RegisterRoutes(r)
+
+	spec := handler.OpenAPISpec(handler.Routes())
+	paths := spec["paths"].(map[string]interface{})
+
+	err := r.Walk(func(route *mux.Route, _ *mux.Router,
+		_ []*mux.Route) error {
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			t.Errorf("Route %s has no OpenAPI path", path)
+			return nil
+		}
+		for _, method := range methods {
+			if _, ok := item[strings.ToLower(method)]; !ok {
+				t.Errorf("Route %s %s has no OpenAPI operation",
+					method, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// v1Routes lists routes served outside /api/v2, maintained by hand so that
+// routes dropped or added to the route table by mistake are noticed. Vote
+// casting is disabled by default and tested separately.
+var v1Routes = map[string][]string{
+	"/api/ping":                              {"GET"},
+	"/api/getconnectionslist":                {"GET"},
+	"/api/consistency":                       {"GET"},
+	"/api/cache/stats":                       {"GET"},
+	"/api/openapi.json":                      {"GET"},
+	"/metrics":                               {"GET"},
+	"/api/batch":                             {"POST"},
+	"/rpc":                                   {"POST"},
+	"/graphql":                               {"GET", "POST"},
+	"/api/consensus/genesis":                 {"GET"},
+	"/api/consensus/epoch":                   {"GET"},
+	"/api/consensus/epochtiming":             {"GET"},
+	"/api/consensus/epochdate":               {"GET"},
+	"/api/consensus/block":                   {"GET"},
+	"/api/consensus/status":                  {"GET"},
+	"/api/consensus/height":                  {"GET"},
+	"/api/consensus/blockheader":             {"GET"},
+	"/api/consensus/blocklastcommit":         {"GET"},
+	"/api/consensus/pubkeyaddress":           {"GET"},
+	"/api/consensus/pubkeybech32address":     {"GET"},
+	"/api/consensus/base64bech32address":     {"GET"},
+	"/api/consensus/transactions":            {"GET"},
+	"/api/consensus/transactionswithresults": {"GET"},
+	"/api/pingnode":                          {"GET"},
+	"/api/registry/entities":                 {"GET"},
+	"/api/registry/nodes":                    {"GET"},
+	"/api/registry/nodestatus":               {"GET"},
+	"/api/registry/events":                   {"GET"},
+	"/api/registry/runtimes":                 {"GET"},
+	"/api/registry/genesis":                  {"GET"},
+	"/api/registry/entity":                   {"GET"},
+	"/api/registry/node":                     {"GET"},
+	"/api/registry/runtime":                  {"GET"},
+	"/api/staking/totalsupply":               {"GET"},
+	"/api/staking/commonpool":                {"GET"},
+	"/api/staking/genesis":                   {"GET"},
+	"/api/staking/threshold":                 {"GET"},
+	"/api/staking/accounts":                  {"GET"},
+	"/api/staking/accountinfo":               {"GET"},
+	"/api/staking/delegations":               {"GET"},
+	"/api/staking/debondingdelegations":      {"GET"},
+	"/api/staking/events":                    {"GET"},
+	"/api/nodecontroller/synced":             {"GET"},
+	"/api/nodecontroller/status":             {"GET"},
+	"/api/nodecontroller/waitready":          {"GET"},
+	"/api/scheduler/validators":              {"GET"},
+	"/api/scheduler/committees":              {"GET"},
+	"/api/scheduler/genesis":                 {"GET"},
+	"/api/scheduler/nodecommittees":          {"GET"},
+	"/api/scheduler/validatoroutlook":        {"GET"},
+	"/api/prometheus/gauge":                  {"GET"},
+	"/api/prometheus/counter":                {"GET"},
+	"/api/exporter/gauge":                    {"GET"},
+	"/api/exporter/counter":                  {"GET"},
+	"/api/sentry/addresses":                  {"GET"},
+	"/api/governance/activeproposals":        {"GET"},
+	"/api/governance/proposals":              {"GET"},
+	"/api/governance/proposal":               {"GET"},
+	"/api/governance/votes":                  {"GET"},
+	"/api/governance/tally":                  {"GET"},
+	"/api/governance/pendingupgrades":        {"GET"},
+	"/api/governance/parameters":             {"GET"},
+	"/api/governance/timeline":               {"GET"},
+	"/api/beacon/state":                      {"GET"},
+	"/api/beacon/watchepochs":                {"GET"},
+	"/api/roothash/events":                   {"GET"},
+	"/api/roothash/blocks":                   {"GET"},
+}
+
+func TestRegisterRoutes_Expected(t *testing.T) {
+	r := mux.NewRouter()
+	router.RegisterRoutes(r)
+
+	registered := map[string]bool{}
+	err := r.Walk(func(route *mux.Route, _ *mux.Router,
+		_ []*mux.Route) error {
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(path, "/api/v2/") {
+			return nil
+		}
+
+		registered[path] = true
+		expected, ok := v1Routes[path]
+		if !ok {
+			t.Errorf("Route %s is registered but not expected", path)
+		} else if strings.Join(methods, ",") != strings.Join(expected, ",") {
+			t.Errorf("Route %s is registered for %v, expected %v", path,
+				methods, expected)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path := range v1Routes {
+		if !registered[path] {
+			t.Errorf("Route %s is expected but not registered", path)
+		}
+	}
+}
+
+func TestRoutes_Described(t *testing.T) {
+	rpcMethods := map[string]bool{}
+	for _, route := range handler.Routes() {
+		if len(route.Summary) == 0 || len(route.Group) == 0 {
+			t.Errorf("Route %s has no summary or group", route.Path)
+		}
+		if route.Handler == nil && route.NewHandler == nil {
+			t.Errorf("Route %s has no handler", route.Path)
+		}
+		if len(route.RPCMethod) > 0 {
+			if rpcMethods[route.RPCMethod] {
+				t.Errorf("JSON-RPC method %s is used twice",
+					route.RPCMethod)
+			}
+			rpcMethods[route.RPCMethod] = true
+		}
+	}
+}
+
+func TestOpenAPISpec_References(t *testing.T) {
+	spec := handler.OpenAPISpec(handler.Routes())
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every referenced schema must be described in components
+	schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	for _, part := range strings.Split(string(data),
+		`"$ref":"#/components/schemas/`)[1:] {
+		name := part[:strings.Index(part, `"`)]
+		if schema, ok := schemas[name]; !ok || schema == nil {
+			t.Errorf("Schema %s is referenced but not described", name)
+		}
+	}
+}
+
+func TestRegisterRoutes_VoteCastingDisabled(t *testing.T) {
+	r := mux.NewRouter()
+	router.RegisterRoutes(r)
+
+	for _, path := range []string{"/api/governance/castvote",
+		"/api/v2/governance/castvote"} {
+		req := httptest.NewRequest("POST", path, nil)
+		var match mux.RouteMatch
+		if r.Match(req, &match) {
+			t.Errorf("Route %s is registered while vote casting is "+
+				"disabled", path)
+		}
+	}
+}
+
+func TestImmutableRequest(t *testing.T) {
+	for path, immutable := range map[string]bool{
+		"/api/consensus/block?name=a&height=5":                    true,
+		"/api/consensus/block?name=a":                             false,
+		"/api/v2/consensus/block?node=a&height=5":                 true,
+		"/api/scheduler/nodecommittees?name=a&height=5":           true,
+		"/api/scheduler/nodecommittees?name=a&height=5&next=true": false,
+		"/api/consensus/status?name=a&height=5":                   false,
+		"/api/prometheus/gauge?name=a&height=5":                   false,
+		"/api/exporter/counter?name=a&height=5":                   false,
+		"/graphql?name=a&height=5":                                false,
+	} {
+		req := httptest.NewRequest("GET", path, nil)
+		if handler.ImmutableRequest(req) != immutable {
+			t.Errorf("Expected immutability of %s to be %v", path,
+				immutable)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package router
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	lgr "github.com/SimplyVC/oasis_api_server/src/logger"
+)
+
+// Interval at which certificate files are checked for changes
+const certCheckInterval = 10 * time.Second
+
+// certReloader serves certificate loaded from files, reloading it when
+// either file changes so that renewed certificates are used without restart
+type certReloader struct {
+	certPath string
+	keyPath  string
+
+	mutex   sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+}
+
+// newCertReloader loads certificate and key from files
+func newCertReloader(certPath string, keyPath string) (*certReloader,
+	error) {
+
+	reloader := &certReloader{certPath: certPath, keyPath: keyPath}
+	if _, err := reloader.reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// modified returns latest modification time of certificate and key
+func (c *certReloader) modified() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{c.certPath, c.keyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// reload loads certificate and key if either file changed since they were
+// last loaded, returning whether they were
+func (c *certReloader) reload() (bool, error) {
+	modTime, err := c.modified()
+	if err != nil {
+		return false, err
+	}
+
+	c.mutex.RLock()
+	unchanged := c.cert != nil && modTime.Equal(c.modTime)
+	c.mutex.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	// Certificate in use is kept if files can't be loaded, they may still
+	// be written
+	cert, err := tls.LoadX509KeyPair(c.certPath, c.keyPath)
+	if err != nil {
+		return false, err
+	}
+
+	c.mutex.Lock()
+	c.cert = &cert
+	c.modTime = modTime
+	c.mutex.Unlock()
+	return true, nil
+}
+
+// watch reloads certificate whenever its files change
+func (c *certReloader) watch(interval time.Duration) {
+	for range time.Tick(interval) {
+		reloaded, err := c.reload()
+		if err != nil {
+			lgr.Error.Println("Reloading of TLS certificate has failed : ",
+				err)
+		} else if reloaded {
+			lgr.Info.Println("Reloaded TLS certificate from " + c.certPath)
+		}
+	}
+}
+
+// GetCertificate returns certificate last loaded
+func (c *certReloader) GetCertificate(
+	*tls.ClientHelloInfo) (*tls.Certificate, error) {
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.cert, nil
+}
+
+// serverTLSConfig returns TLS configuration serving certificate and key
+// loaded from files, and requiring client certificates signed by CA of
+// clientCAPath if given
+func serverTLSConfig(certPath string, keyPath string,
+	clientCAPath string) (*tls.Config, *certReloader, error) {
+
+	reloader, err := newCertReloader(certPath, keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	// Verify clients if mutual TLS is used
+	if len(clientCAPath) > 0 {
+		b, err := ioutil.ReadFile(clientCAPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(b) {
+			return nil, nil, errors.New("credentials: failed to append " +
+				"certificates")
+		}
+		tlsConfig.ClientCAs = certPool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, reloader, nil
+}
@@ -0,0 +1,108 @@
+package router
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes new self-signed certificate and its key to files
+// in dir, modified at given time
+func writeCertificate(t *testing.T, dir string, serial int64,
+	modTime time.Time) (string, string) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key got %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "oasis-api-server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template,
+		&key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate got %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key got %v", err)
+	}
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: der}), 0600)
+	ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+		Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	os.Chtimes(certPath, modTime, modTime)
+	os.Chtimes(keyPath, modTime, modTime)
+	return certPath, keyPath
+}
+
+// serial returns serial number of certificate served by reloader
+func serial(t *testing.T, reloader *certReloader) int64 {
+	cert, _ := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("Failed to parse certificate got %v", err)
+	}
+	return parsed.SerialNumber.Int64()
+}
+
+// Testing if certificate is reloaded once its files change
+func TestCertReloader_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatalf("Failed to create directory got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	modTime := time.Now().Add(-time.Minute)
+	certPath, keyPath := writeCertificate(t, dir, 1, modTime)
+	reloader, err := newCertReloader(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("Failed to load certificate got %v", err)
+	}
+
+	if reloaded, err := reloader.reload(); reloaded || err != nil {
+		t.Errorf("Expected unchanged certificate to be kept got %v %v",
+			reloaded, err)
+	}
+
+	writeCertificate(t, dir, 2, modTime.Add(time.Second))
+	if reloaded, err := reloader.reload(); !reloaded || err != nil {
+		t.Errorf("Expected changed certificate to be reloaded got %v %v",
+			reloaded, err)
+	}
+	if got := serial(t, reloader); got != 2 {
+		t.Errorf("Expected certificate with serial 2 got %v", got)
+	}
+}
+
+// Testing if TLS settings with missing client CA file are rejected
+func TestServerTLSConfig_MissingClientCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	if err != nil {
+		t.Fatalf("Failed to create directory got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	certPath, keyPath := writeCertificate(t, dir, 1, time.Now())
+	_, _, err = serverTLSConfig(certPath, keyPath, "/nonexistent/ca.pem")
+	if err == nil {
+		t.Errorf("Expected serverTLSConfig to fail for missing client CA")
+	}
+}
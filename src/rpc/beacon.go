@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"context"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+// pvssStateKeyFmt is the key under which beacon application stores the
+// current PVSS round in consensus state.
+var pvssStateKeyFmt = keyformat.New(0x44)
+
+// PVSSState reads PVSS round state at specified block height directly from
+// consensus state, as nodes do not expose it through the beacon service.
+// Returns nil state if beacon is not running a PVSS round.
+func PVSSState(ctx context.Context, client consensus.ClientBackend,
+	height int64) (*beacon.PVSSState, error) {
+
+	// Retrieve block to get state root at specified height
+	blk, err := client.GetBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open consensus state tree at block state root through node
+	tree := mkvs.NewWithRoot(client.State(), nil, blk.StateRoot)
+	defer tree.Close()
+
+	data, err := tree.Get(ctx, pvssStateKeyFmt.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var state beacon.PVSSState
+	if err = cbor.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
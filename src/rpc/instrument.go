@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+)
+
+// instrumentCalls returns interceptor measuring latency and errors of unary
+// calls to address
+func instrumentCalls(address string) grpc.UnaryClientInterceptor {
+	name := nodeName(address)
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		metrics.CallDuration.WithLabelValues(name, method).Observe(
+			time.Since(start).Seconds())
+		if err != nil {
+			metrics.CallErrors.WithLabelValues(name, method,
+				status.Code(err).String()).Inc()
+		}
+		return err
+	}
+}
+
+// connStats counts transports connected to node
+type connStats struct {
+	name string
+}
+
+func (s *connStats) TagRPC(ctx context.Context,
+	info *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (s *connStats) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
+}
+
+func (s *connStats) TagConn(ctx context.Context,
+	info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn updates number of transports connected to node
+func (s *connStats) HandleConn(ctx context.Context, event stats.ConnStats) {
+	switch event.(type) {
+	case *stats.ConnBegin:
+		metrics.ConnectionsOpen.WithLabelValues(s.name).Inc()
+	case *stats.ConnEnd:
+		metrics.ConnectionsOpen.WithLabelValues(s.name).Dec()
+	}
+}
+
+// dialOptions returns options limiting and instrumenting connection to
+// address
+func dialOptions(address string) []grpc.DialOption {
+	name := nodeName(address)
+	metrics.Connections.WithLabelValues(name).Inc()
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(limitCalls(address),
+			instrumentCalls(address)),
+		grpc.WithStatsHandler(&connStats{name: name}),
+	}
+}
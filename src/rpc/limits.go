@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/SimplyVC/oasis_api_server/src/config"
+	"github.com/SimplyVC/oasis_api_server/src/metrics"
+)
+
+// ErrCallLimit is returned by calls to nodes rejected because too many
+// calls were outstanding
+var ErrCallLimit = status.Error(codes.ResourceExhausted,
+	"too many outstanding calls to nodes")
+
+// Limits of outstanding calls to all nodes and to each node, 0 if
+// unlimited, together with number of calls outstanding
+var (
+	callMutex    sync.Mutex
+	maxCalls     int
+	maxNodeCalls int
+	totalCalls   int
+	nodeCalls    = map[string]int{}
+)
+
+// Unary calls blocking until node is synced or ready, which aren't limited
+// as they stay outstanding for as long as they wait
+var blockingMethods = map[string]bool{
+	"/oasis-core.NodeController/WaitSync":  true,
+	"/oasis-core.NodeController/WaitReady": true,
+}
+
+// SetCallLimits sets maximum number of outstanding unary calls to all nodes
+// and to each node, 0 for no limit. Streams and blocking calls aren't
+// limited as they stay open for as long as they're watched.
+func SetCallLimits(total int, perNode int) {
+	callMutex.Lock()
+	defer callMutex.Unlock()
+	maxCalls = total
+	maxNodeCalls = perNode
+}
+
+// available returns whether another call to address is within limits,
+// callMutex needs to be held
+func available(address string) bool {
+	return (maxCalls <= 0 || totalCalls < maxCalls) &&
+		(maxNodeCalls <= 0 || nodeCalls[address] < maxNodeCalls)
+}
+
+// CallsAvailable returns whether a call to node at address would currently
+// be within limits
+func CallsAvailable(address string) bool {
+	callMutex.Lock()
+	defer callMutex.Unlock()
+	return available(address)
+}
+
+// nodeName returns name of node configured with address, address itself if
+// it isn't configured
+func nodeName(address string) string {
+	for _, node := range config.GetNodes() {
+		if node["isocket_path"] == address {
+			return node["node_name"]
+		}
+	}
+	return address
+}
+
+// limitCalls returns interceptor rejecting unary calls to address exceeding
+// limits of outstanding calls
+func limitCalls(address string) grpc.UnaryClientInterceptor {
+	name := nodeName(address)
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption) error {
+
+		if blockingMethods[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		callMutex.Lock()
+		if !available(address) {
+			callMutex.Unlock()
+			metrics.CallsRejected.WithLabelValues(name).Inc()
+			return ErrCallLimit
+		}
+		totalCalls++
+		nodeCalls[address]++
+		callMutex.Unlock()
+		metrics.CallsOutstanding.WithLabelValues(name).Inc()
+
+		defer func() {
+			callMutex.Lock()
+			totalCalls--
+			if nodeCalls[address]--; nodeCalls[address] == 0 {
+				delete(nodeCalls, address)
+			}
+			callMutex.Unlock()
+			metrics.CallsOutstanding.WithLabelValues(name).Dec()
+		}()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
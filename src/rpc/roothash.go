@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	common_namespace "github.com/oasisprotocol/oasis-core/go/common"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// RootHash is the set of roothash queries that can be answered by a node.
+// Nodes do not expose a RootHash gRPC service, so events are taken from the
+// consensus transaction results and blocks from the runtime client service.
+type RootHash interface {
+	// GetEvents returns the roothash events emitted by transactions at
+	// specified block height.
+	GetEvents(ctx context.Context, height int64) ([]*roothash.Event, error)
+
+	// GetGenesisBlock returns the genesis block of a runtime.
+	GetGenesisBlock(ctx context.Context,
+		runtimeID common_namespace.Namespace) (*block.Block, error)
+
+	// GetBlock returns the runtime block at specified round.
+	GetBlock(ctx context.Context, runtimeID common_namespace.Namespace,
+		round uint64) (*block.Block, error)
+
+	// GetLatestBlock returns the latest runtime block.
+	GetLatestBlock(ctx context.Context,
+		runtimeID common_namespace.Namespace) (*block.Block, error)
+}
+
+type rootHashClient struct {
+	consensus consensus.ClientBackend
+	runtime   runtimeClient.RuntimeClient
+}
+
+// GetEvents filters roothash events out of the block transaction results
+func (c *rootHashClient) GetEvents(ctx context.Context,
+	height int64) ([]*roothash.Event, error) {
+
+	txsWithResults, err := c.consensus.GetTransactionsWithResults(ctx,
+		height)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []*roothash.Event{}
+	for _, result := range txsWithResults.Results {
+		for _, event := range result.Events {
+			if event.RootHash != nil {
+				events = append(events, event.RootHash)
+			}
+		}
+	}
+	return events, nil
+}
+
+// GetGenesisBlock returns genesis block from runtime client
+func (c *rootHashClient) GetGenesisBlock(ctx context.Context,
+	runtimeID common_namespace.Namespace) (*block.Block, error) {
+	return c.runtime.GetGenesisBlock(ctx, runtimeID)
+}
+
+// GetBlock returns block at given round from runtime client
+func (c *rootHashClient) GetBlock(ctx context.Context,
+	runtimeID common_namespace.Namespace, round uint64) (*block.Block,
+	error) {
+	return c.runtime.GetBlock(ctx, &runtimeClient.GetBlockRequest{
+		RuntimeID: runtimeID,
+		Round:     round,
+	})
+}
+
+// GetLatestBlock returns latest block from runtime client
+func (c *rootHashClient) GetLatestBlock(ctx context.Context,
+	runtimeID common_namespace.Namespace) (*block.Block, error) {
+	return c.GetBlock(ctx, runtimeID, runtimeClient.RoundLatest)
+}
+
+// newRootHashClient creates roothash client on top of an open connection
+func newRootHashClient(conn *grpc.ClientConn) RootHash {
+	return &rootHashClient{
+		consensus: consensus.NewConsensusClient(conn),
+		runtime:   runtimeClient.NewRuntimeClient(conn),
+	}
+}
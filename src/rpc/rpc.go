@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -131,6 +132,18 @@ func ConsensusLightClient(address string) (*grpc.ClientConn,
 	return conn, client, nil
 }
 
+// RootHashClient - initiate new roothash client
+func RootHashClient(address string) (*grpc.ClientConn, RootHash, error) {
+	conn, err := Connect(address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to establish connection "+
+			"with node %s", address)
+	}
+
+	client := newRootHashClient(conn)
+	return conn, client, nil
+}
+
 // StakingClient - initiate new staking client
 func StakingClient(address string) (*grpc.ClientConn, staking.Backend, error) {
 	conn, err := Connect(address)
@@ -166,10 +179,11 @@ func ConnectTLS(address string, tlsPath string) (*grpc.ClientConn, error) {
 	})
 
 	// Add Credentials to grpc options to be used for TLS Connection
-	opts := grpc.WithTransportCredentials(creds)
+	opts := append(dialOptions(address),
+		grpc.WithTransportCredentials(creds))
 	conn, err := cmnGrpc.Dial(
 		address,
-		opts,
+		opts...,
 	)
 	if err != nil {
 		return nil, err
@@ -178,13 +192,72 @@ func ConnectTLS(address string, tlsPath string) (*grpc.ClientConn, error) {
 	return conn, nil
 }
 
-// Connect - connect to grpc
+// TLSOptions holds TLS settings of node reached over TCP
+type TLSOptions struct {
+	// CAPath is file containing CA certificates used to verify node,
+	// system certificates are used if empty
+	CAPath string
+	// ServerName overrides name expected in node certificate
+	ServerName string
+	// CertPath and KeyPath hold client certificate for mutual TLS
+	CertPath string
+	KeyPath  string
+}
+
+// Transport credentials of nodes that are connected to using TLS
+var (
+	nodeCredsMutex sync.RWMutex
+	nodeCreds      = map[string]credentials.TransportCredentials{}
+)
+
+// RegisterNodeTLS loads TLS settings used for every connection to address
+func RegisterNodeTLS(address string, options *TLSOptions) error {
+	tlsConfig := &tls.Config{ServerName: options.ServerName}
+
+	// Add CA certificates to a certificate pool
+	if len(options.CAPath) > 0 {
+		b, err := ioutil.ReadFile(options.CAPath)
+		if err != nil {
+			return err
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(b) {
+			return fmt.Errorf("credentials: failed to append " +
+				"certificates")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	// Load client certificate if mutual TLS is used
+	if len(options.CertPath) > 0 || len(options.KeyPath) > 0 {
+		cert, err := tls.LoadX509KeyPair(options.CertPath, options.KeyPath)
+		if err != nil {
+			return err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	nodeCredsMutex.Lock()
+	nodeCreds[address] = credentials.NewTLS(tlsConfig)
+	nodeCredsMutex.Unlock()
+	return nil
+}
+
+// Connect - connect to grpc, using TLS if registered for address
 // Add grpc.WithBlock() and grpc.WithTimeout()
 // to have dial to constantly try and establish connection
 func Connect(address string) (*grpc.ClientConn, error) {
+	nodeCredsMutex.RLock()
+	creds, ok := nodeCreds[address]
+	nodeCredsMutex.RUnlock()
+
 	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if ok {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	}
 	opts = append(opts, grpc.WithDefaultCallOptions(
 		grpc.WaitForReady(false)))
+	opts = append(opts, dialOptions(address)...)
 
 	conn, err := cmnGrpc.Dial(
 		address,
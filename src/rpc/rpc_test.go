@@ -3,8 +3,12 @@ package rpc_test
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
 
 	"github.com/SimplyVC/oasis_api_server/src/rpc"
 )
@@ -87,3 +91,107 @@ func TestConnect_Success(t *testing.T) {
 			isocket_path, err)
 	}
 }
+
+// Testing if TLS settings with missing CA file are rejected
+func TestRegisterNodeTLS_MissingCA(t *testing.T) {
+	err := rpc.RegisterNodeTLS("127.0.0.1:9001", &rpc.TLSOptions{
+		CAPath: "/nonexistent/ca.pem"})
+	if err == nil {
+		t.Errorf("Expected RegisterNodeTLS to fail for missing CA file")
+	}
+}
+
+// Testing if TLS settings without files are accepted
+func TestRegisterNodeTLS_ServerNameOnly(t *testing.T) {
+	err := rpc.RegisterNodeTLS("127.0.0.1:9001", &rpc.TLSOptions{
+		ServerName: "oasis-node"})
+	if err != nil {
+		t.Errorf("Failed to register TLS settings got %v", err)
+	}
+}
+
+// Testing if calls exceeding limit of outstanding calls are rejected
+func TestSetCallLimits_Rejected(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen got %v", err)
+	}
+
+	// Server holds every call until released
+	release := make(chan struct{})
+	server := grpc.NewServer(grpc.UnknownServiceHandler(
+		func(srv interface{}, stream grpc.ServerStream) error {
+			<-release
+			return nil
+		}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	address := listener.Addr().String()
+	conn, err := rpc.Connect(address)
+	if err != nil {
+		t.Fatalf("Failed to create connection got %v", err)
+	}
+	defer conn.Close()
+
+	rpc.SetCallLimits(0, 1)
+	defer rpc.SetCallLimits(0, 0)
+
+	go conn.Invoke(ctx, "/test.Service/Hold", nil, nil)
+	for i := 0; rpc.CallsAvailable(address); i++ {
+		if i == 100 {
+			t.Fatalf("Call to %v never became outstanding", address)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	err = conn.Invoke(ctx, "/test.Service/Hold", nil, nil)
+	if err != rpc.ErrCallLimit {
+		t.Errorf("Expected call over limit to fail with %v got %v",
+			rpc.ErrCallLimit, err)
+	}
+	close(release)
+}
+
+// Testing if calls blocking until node is ready don't count as outstanding
+func TestSetCallLimits_BlockingExempt(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen got %v", err)
+	}
+
+	// Server holds every call until released, signalling calls received
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := grpc.NewServer(grpc.UnknownServiceHandler(
+		func(srv interface{}, stream grpc.ServerStream) error {
+			received <- struct{}{}
+			<-release
+			return nil
+		}))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	address := listener.Addr().String()
+	conn, err := rpc.Connect(address)
+	if err != nil {
+		t.Fatalf("Failed to create connection got %v", err)
+	}
+	defer conn.Close()
+
+	rpc.SetCallLimits(0, 1)
+	defer rpc.SetCallLimits(0, 0)
+
+	go conn.Invoke(ctx, "/oasis-core.NodeController/WaitReady", nil, nil)
+	select {
+	case <-received:
+	case <-time.After(time.Second):
+		t.Fatalf("Call to %v was never received", address)
+	}
+
+	if !rpc.CallsAvailable(address) {
+		t.Errorf("Expected calls to %v to be available while waiting "+
+			"for node to be ready", address)
+	}
+	close(release)
+}